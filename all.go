@@ -0,0 +1,39 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "io"
+
+// DecodeAll reads r to the end and decodes its content as one base62
+// string. As with the stream decoder of NewDecoder, ASCII spaces, tabs and
+// line breaks are skipped wherever they are, so trailing newlines and
+// wrapped lines decode as is; any other character outside the alphabet is
+// an error. Unlike NewDecoder, the content is one number, as written by
+// EncodeAll, not the block format.
+func DecodeAll(enc *Encoding, r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	src := data[:0]
+	for _, c := range data {
+		if !isSpace(c) {
+			src = append(src, c)
+		}
+	}
+	n, err := enc.DecodeInPlace(src)
+	if err != nil {
+		return nil, err
+	}
+	return src[:n], nil
+}
+
+// EncodeAll writes the encoding of data to w as one base62 string
+// followed by a newline, the format DecodeAll reads.
+func EncodeAll(enc *Encoding, w io.Writer, data []byte) error {
+	buf := enc.AppendEncode(nil, data)
+	_, err := w.Write(append(buf, '\n'))
+	return err
+}
@@ -0,0 +1,62 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestEncodeAllDecodeAll(t *testing.T) {
+	for _, size := range []int{0, 1, 8, 100, 1000} {
+		in := make([]byte, size)
+		rand.Read(in)
+		if size > 2 {
+			in[0], in[1] = 0, 0
+		}
+		var buf bytes.Buffer
+		if err := base62.EncodeAll(base62.StdEncoding, &buf, in); err != nil {
+			t.Fatalf("EncodeAll() = %v", err)
+		}
+		if want := base62.StdEncoding.EncodeToString(in) + "\n"; buf.String() != want {
+			t.Errorf("EncodeAll(%d bytes) = %q, want %q", size, buf.String(), want)
+		}
+		got, err := base62.DecodeAll(base62.StdEncoding, iotest.OneByteReader(&buf))
+		if err != nil || !bytes.Equal(got, in) {
+			t.Errorf("DecodeAll(EncodeAll(%d bytes)) = %x, %v, want %x", size, got, err, in)
+		}
+	}
+}
+
+func TestDecodeAll(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"", ""},
+		{"qMin", "abc"},
+		{"qMin\n", "abc"},
+		{"qMin\r\n\n", "abc"},
+		{"  qM\n in\t", "abc"},
+	}
+	for _, tt := range tests {
+		got, err := base62.DecodeAll(base62.StdEncoding, strings.NewReader(tt.in))
+		if err != nil || string(got) != tt.out {
+			t.Errorf("DecodeAll(%q) = %q, %v, want %q", tt.in, got, err, tt.out)
+		}
+	}
+	for _, in := range []string{"qM?in", "qMin\v", "qM-in"} {
+		if got, err := base62.DecodeAll(base62.StdEncoding, strings.NewReader(in)); err == nil {
+			t.Errorf("DecodeAll(%q) = %q, expected error", in, got)
+		}
+	}
+	if _, err := base62.DecodeAll(base62.StdEncoding, iotest.ErrReader(errWrite)); err != errWrite {
+		t.Errorf("DecodeAll() = %v, want the read error", err)
+	}
+}
@@ -0,0 +1,84 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"math"
+)
+
+// Bits returns the number of bits of information that n characters of the
+// encoding can carry, n*log2(62).
+func (e *Encoding) Bits(n int) float64 {
+	return float64(n) * math.Log2(float64(e.Radix()))
+}
+
+// EnsureEntropy returns an error unless token is made of alphabet characters
+// and is long enough to carry at least minBits bits. It guards token
+// generators against a misconfigured, too small, byte count.
+func (e *Encoding) EnsureEntropy(token string, minBits float64) error {
+	for i := 0; i < len(token); i++ {
+		if e.digitMap()[token[i]] == 255 {
+			return fmt.Errorf("invalid character %q in base62 token %q", token[i], token)
+		}
+	}
+	if bits := e.Bits(len(token)); bits < minBits {
+		return fmt.Errorf("base62 token of %d characters carries %.1f bits, want at least %.1f", len(token), bits, minBits)
+	}
+	return nil
+}
+
+// DigitHistogram counts how many times each digit value occurs in s. A
+// skewed histogram over many generated tokens hints at a biased generator.
+func (e *Encoding) DigitHistogram(s string) ([62]int, error) {
+	var hist [62]int
+	for i := 0; i < len(s); i++ {
+		d := e.decodeMap[s[i]]
+		if d == 255 {
+			return hist, fmt.Errorf("invalid character %q in base62 string %q", s[i], s)
+		}
+		hist[d]++
+	}
+	return hist, nil
+}
+
+// Sample size thresholds of AnalyzeData, in bytes.
+const (
+	analyzeSmall = 8
+	analyzeLarge = 1024
+)
+
+// AnalyzeData is a design-time advisor recommending the radix to encode
+// values like samples with, together with the reason. The heuristic only
+// looks at the sizes: values up to 8 bytes cost at most two more characters
+// in case-insensitive base36, values over 1 KiB are better served by the
+// linear time base64 than by the quadratic base62 arithmetic, and base62 is
+// recommended in between.
+func AnalyzeData(samples [][]byte) (recommendedRadix int, reason string) {
+	maxLen, total := 0, 0
+	for _, s := range samples {
+		if len(s) > maxLen {
+			maxLen = len(s)
+		}
+		total += len(s)
+	}
+	chars := func(radix, n int) int {
+		return int(math.Ceil(float64(n) * 8 / math.Log2(float64(radix))))
+	}
+	switch {
+	case maxLen == 0:
+		return 62, "no data to analyze, base62 is the general purpose default"
+	case maxLen <= analyzeSmall:
+		return 36, fmt.Sprintf("values of at most %d bytes take %d characters in base36 against %d in base62, "+
+			"case-insensitive base36 survives case folding for little cost", maxLen, chars(36, maxLen), chars(62, maxLen))
+	case maxLen > analyzeLarge:
+		return 64, fmt.Sprintf("values of up to %d bytes (%d on average) need big integer arithmetic in base62, "+
+			"quadratic in their length, base64 encodes them in linear time and %d characters instead of %d",
+			maxLen, total/len(samples), chars(64, maxLen), chars(62, maxLen))
+	default:
+		return 62, fmt.Sprintf("values of up to %d bytes take %d characters in base62, "+
+			"the densest alphanumeric encoding at a moderate cost", maxLen, chars(62, maxLen))
+	}
+}
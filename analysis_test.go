@@ -0,0 +1,84 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"math"
+	"testing"
+)
+
+func TestBits(t *testing.T) {
+	if got, want := base62.StdEncoding.Bits(22), 22*math.Log2(62); got != want {
+		t.Errorf("Bits(22) = %f, want %f", got, want)
+	}
+	if got := base62.StdEncoding.Bits(0); got != 0 {
+		t.Errorf("Bits(0) = %f, want 0", got)
+	}
+}
+
+func TestEnsureEntropy(t *testing.T) {
+	token := base62.StdEncoding.EncodeToString([]byte("0123456789abcdef")) // 16 bytes
+	bits := base62.StdEncoding.Bits(len(token))
+	if err := base62.StdEncoding.EnsureEntropy(token, bits-1); err != nil {
+		t.Errorf("EnsureEntropy below threshold = %v, want nil", err)
+	}
+	if err := base62.StdEncoding.EnsureEntropy(token, bits); err != nil {
+		t.Errorf("EnsureEntropy at threshold = %v, want nil", err)
+	}
+	if err := base62.StdEncoding.EnsureEntropy(token, bits+1); err == nil {
+		t.Errorf("EnsureEntropy above threshold = nil, want error")
+	}
+	if err := base62.StdEncoding.EnsureEntropy("abc?", 1); err == nil {
+		t.Errorf("EnsureEntropy of an invalid token = nil, want error")
+	}
+}
+
+func TestDigitHistogram(t *testing.T) {
+	s := "00aZZZ9"
+	hist, err := base62.StdEncoding.DigitHistogram(s)
+	if err != nil {
+		t.Fatalf("DigitHistogram(%s) failed: %v", s, err)
+	}
+	want := map[int]int{0: 2, 10: 1, 61: 3, 9: 1}
+	sum := 0
+	for d, n := range hist {
+		sum += n
+		if n != want[d] {
+			t.Errorf("DigitHistogram(%s)[%d] = %d, want %d", s, d, n, want[d])
+		}
+	}
+	if sum != len(s) {
+		t.Errorf("DigitHistogram(%s) sums to %d, want %d", s, sum, len(s))
+	}
+	if _, err := base62.StdEncoding.DigitHistogram("ab-c"); err == nil {
+		t.Errorf("DigitHistogram of an invalid string should fail")
+	}
+}
+
+var analyzeTests = []struct {
+	name    string
+	samples [][]byte
+	radix   int
+}{
+	{"none", nil, 62},
+	{"empty", [][]byte{{}}, 62},
+	{"uint64 ids", [][]byte{make([]byte, 8), make([]byte, 4)}, 36},
+	{"uuids", [][]byte{make([]byte, 16), make([]byte, 16)}, 62},
+	{"keys", [][]byte{make([]byte, 32), make([]byte, 1024)}, 62},
+	{"blobs", [][]byte{make([]byte, 100), make([]byte, 4096)}, 64},
+}
+
+func TestAnalyzeData(t *testing.T) {
+	for _, tt := range analyzeTests {
+		radix, reason := base62.AnalyzeData(tt.samples)
+		if radix != tt.radix {
+			t.Errorf("AnalyzeData(%s) = %d, %q, want %d", tt.name, radix, reason, tt.radix)
+		}
+		if reason == "" {
+			t.Errorf("AnalyzeData(%s) gives no reason", tt.name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// EncodeAny encodes v with the method matching its type:
+//
+//	[]byte, string  EncodeToString
+//	[16]byte        EncodeToString of the array bytes
+//	uint64          EncodeUint64
+//	int64           EncodeInt64
+//	*big.Int        EncodeBigInt
+//
+// An error is returned for any other type and for negative or nil
+// *big.Int values, which EncodeBigInt does not accept.
+func (e *Encoding) EncodeAny(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case []byte:
+		return e.EncodeToString(v), nil
+	case string:
+		return e.EncodeToString([]byte(v)), nil
+	case [16]byte:
+		return e.EncodeToString(v[:]), nil
+	case uint64:
+		return e.EncodeUint64(v), nil
+	case int64:
+		return e.EncodeInt64(v), nil
+	case *big.Int:
+		if v == nil || v.Sign() < 0 {
+			return "", fmt.Errorf("cannot encode big.Int %v in base62, want a non-negative value", v)
+		}
+		return e.EncodeBigInt(v), nil
+	}
+	return "", fmt.Errorf("cannot encode value of type %T in base62", v)
+}
@@ -0,0 +1,37 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeAny(t *testing.T) {
+	enc := base62.StdEncoding
+	uuid := [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	for _, tt := range []struct {
+		v    interface{}
+		want string
+	}{
+		{[]byte("Hello"), enc.EncodeToString([]byte("Hello"))},
+		{"Hello", enc.EncodeToString([]byte("Hello"))},
+		{uuid, enc.EncodeToString(uuid[:])},
+		{uint64(1000000), enc.EncodeUint64(1000000)},
+		{int64(-5), enc.EncodeInt64(-5)},
+		{big.NewInt(256), enc.EncodeBigInt(big.NewInt(256))},
+	} {
+		if got, err := enc.EncodeAny(tt.v); err != nil || got != tt.want {
+			t.Errorf("EncodeAny(%#v) = %q, %v, want %q", tt.v, got, err, tt.want)
+		}
+	}
+	var nilInt *big.Int
+	for _, v := range []interface{}{nil, 1, uint32(1), 1.5, [8]byte{}, big.NewInt(-1), nilInt} {
+		if got, err := enc.EncodeAny(v); err == nil {
+			t.Errorf("EncodeAny(%#v) = %q, want error", v, got)
+		}
+	}
+}
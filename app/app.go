@@ -11,7 +11,10 @@ import (
 	"github.com/schwid/base62"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/jessevdk/go-flags"
@@ -26,12 +29,26 @@ type app struct {
 	inStream  io.Reader
 	outStream io.Writer
 	errStream io.Writer
+
+	// enc is the encoding of the input or output
+	enc *base62.Encoding
+	// wrap is the width of the encoded lines, 0 for no wrapping
+	wrap int
+	// uint treats values as decimal unsigned integers
+	uint bool
+	// garbage marks the bytes dropped before decoding, nil to keep all
+	garbage *[256]bool
 }
 
 type flagopts struct {
 	Decode   bool             `short:"D" long:"decode" description:"decodes input"`
 	Input    []string         `short:"i" long:"input" default:"-" description:"input file"`
 	Output   string           `short:"o" long:"output" default:"-" description:"output file"`
+	OutputDir string          `short:"O" long:"output-dir" description:"output directory, one output file per input file"`
+	Wrap     int              `short:"w" long:"wrap" default:"0" description:"break encoded lines after N characters, a full line continuing on the next one, 0 for no wrapping; with -D join such lines"`
+	Alphabet string           `short:"a" long:"alphabet" description:"62 character alphabet to use instead of the standard one"`
+	Uint     bool             `short:"u" long:"uint" description:"encode decimal unsigned integers, with -D decode to decimal"`
+	IgnoreGarbage bool        `short:"g" long:"ignore-garbage" description:"when decoding, ignore characters outside the alphabet"`
 	Version  bool             `short:"v" long:"version" description:"print version"`
 }
 
@@ -62,12 +79,58 @@ func (cli *app) run(args []string) error {
 		fmt.Fprintf(cli.outStream, "%s %s (build: %s/%s)\n", cli.name, cli.version, cli.build, runtime.Version())
 		return nil
 	}
+	if opts.Wrap < 0 {
+		return fmt.Errorf("invalid wrap width %d", opts.Wrap)
+	}
+	if opts.Uint && opts.Wrap > 0 {
+		return fmt.Errorf("--uint and --wrap cannot be used together")
+	}
+	cli.enc = base62.StdEncoding
+	if opts.Alphabet != "" {
+		if cli.enc, err = base62.NewEncoding(opts.Alphabet); err != nil {
+			return err
+		}
+	}
+	cli.wrap = opts.Wrap
+	cli.uint = opts.Uint
+	if opts.IgnoreGarbage {
+		cli.garbage = garbageTable(cli.enc)
+	}
 	var inputFiles []string
 	for _, name := range append(opts.Input, args...) {
 		if name != "" && name != "-" {
 			inputFiles = append(inputFiles, name)
 		}
 	}
+	if opts.OutputDir != "" {
+		if opts.Output != "-" {
+			return fmt.Errorf("--output and --output-dir cannot be used together")
+		}
+		if len(inputFiles) == 0 {
+			return fmt.Errorf("--output-dir needs input files")
+		}
+		outputs := make(map[string]string, len(inputFiles))
+		for _, name := range inputFiles {
+			out := outputName(opts.Decode, name)
+			if other, ok := outputs[out]; ok {
+				return fmt.Errorf("input files %s and %s would both be written to %s", other, name, out)
+			}
+			outputs[out] = name
+			path := filepath.Join(opts.OutputDir, out)
+			for _, in := range inputFiles {
+				if sameFile(in, path) {
+					return fmt.Errorf("output file %s of %s would overwrite input file %s", path, name, in)
+				}
+			}
+		}
+		var result error
+		for _, name := range inputFiles {
+			if err := cli.runFileToDir(opts.Decode, name, opts.OutputDir); err != nil {
+				result = err
+			}
+		}
+		return result
+	}
 	if opts.Output != "-" {
 		file, err := os.Create(opts.Output)
 		if err != nil {
@@ -78,58 +141,206 @@ func (cli *app) run(args []string) error {
 	}
 	var result error
 	if len(inputFiles) == 0 {
-		if err := cli.runInternal(opts.Decode, cli.inStream); err != nil {
+		if err := cli.runInternal(opts.Decode, cli.inStream, cli.outStream); err != nil {
 			result = err
 		}
 	}
 	for _, name := range inputFiles {
-		if err := cli.runFile(opts.Decode, name); err != nil {
+		if err := cli.runFile(opts.Decode, name, cli.outStream); err != nil {
 			result = err
 		}
 	}
 	return result
 }
 
-func (cli *app) runFile(decode bool, name string) error {
+func (cli *app) runFile(decode bool, name string, out io.Writer) error {
 	file, err := os.Open(name)
 	if err != nil {
 		fmt.Fprintln(cli.errStream, err.Error())
 		return err
 	}
 	defer file.Close()
-	return cli.runInternal(decode, file)
+	return cli.runInternal(decode, file, out)
+}
+
+// outputExt is appended to the names of encoded files in the output
+// directory and removed again when decoding.
+const outputExt = ".b62"
+
+func outputName(decode bool, name string) string {
+	name = filepath.Base(name)
+	if !decode {
+		return name + outputExt
+	}
+	if trimmed := strings.TrimSuffix(name, outputExt); trimmed != name && trimmed != "" {
+		return trimmed
+	}
+	return name + ".decoded"
+}
+
+// sameFile reports whether the paths a and b name the same file, either
+// by their absolute path or, when both exist, by identity.
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA == nil && errB == nil && absA == absB {
+		return true
+	}
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// runFileToDir opens the input before creating the output file, so that
+// no empty output is left behind for an input that cannot be read.
+func (cli *app) runFileToDir(decode bool, name, dir string) error {
+	in, err := os.Open(name)
+	if err != nil {
+		fmt.Fprintln(cli.errStream, err.Error())
+		return err
+	}
+	defer in.Close()
+	file, err := os.Create(filepath.Join(dir, outputName(decode, name)))
+	if err != nil {
+		fmt.Fprintln(cli.errStream, err.Error())
+		return err
+	}
+	err = cli.runInternal(decode, in, file)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
 }
 
-func (cli *app) runInternal(decode bool, in io.Reader) error {
+func (cli *app) runInternal(decode bool, in io.Reader, out io.Writer) error {
 	scanner := bufio.NewScanner(in)
 	var status error
-	var result []byte
+	var result, record []byte
 	var err error
-	for scanner.Scan() {
-		src := scanner.Bytes()
-		if decode {
-			result, err = processLine(src, func(in []byte) ([]byte, error) {
-				return base62.StdEncoding.DecodeString(string(in))
-			})
-		} else {
-			result, err = processLine(src, func(in []byte) ([]byte, error) {
-				return []byte(base62.StdEncoding.EncodeToString(in)), nil
-			})
-		}
+	emit := func(src []byte) {
+		result, err = cli.processLine(decode, result[:0], src, func(err error) {
+			fmt.Fprintln(cli.errStream, err.Error())
+			status = err
+		})
 		if err != nil {
 			fmt.Fprintln(cli.errStream, err.Error()) // should print error each line
 			status = err
-			continue
+			return
 		}
-		cli.outStream.Write(result)
-		cli.outStream.Write([]byte{0x0a})
+		if !decode && cli.wrap > 0 {
+			record = appendWrapped(record[:0], result, cli.wrap)
+			out.Write(record)
+			return
+		}
+		result = append(result, 0x0a)
+		out.Write(result)
+	}
+	for scanner.Scan() {
+		src := scanner.Bytes()
+		if decode && cli.wrap > 0 {
+			// a full line continues on the next one
+			record = append(record, src...)
+			if len(src) == cli.wrap {
+				continue
+			}
+			src = record
+		}
+		emit(src)
+		record = record[:0]
+	}
+	if len(record) > 0 {
+		emit(record)
 	}
 	return status
 }
 
-func processLine(src []byte, f func([]byte) ([]byte, error)) ([]byte, error) {
+// processLine appends to dst the encoding or decoding of the line src. In
+// integer mode each invalid token is passed to report and left out, and
+// the valid tokens of the line are still converted.
+func (cli *app) processLine(decode bool, dst, src []byte, report func(error)) ([]byte, error) {
+	switch {
+	case decode && cli.uint:
+		return processLine(dst, src, func(dst, in []byte) ([]byte, error) {
+			if len(in) == 0 {
+				return dst, nil
+			}
+			n, err := cli.enc.DecodeToUint64(string(cli.dropGarbage(in)))
+			if err != nil {
+				report(err)
+				return dst, nil
+			}
+			return strconv.AppendUint(dst, n, 10), nil
+		})
+	case cli.uint:
+		return processLine(dst, src, func(dst, in []byte) ([]byte, error) {
+			if len(in) == 0 {
+				return dst, nil
+			}
+			n, err := strconv.ParseUint(string(in), 10, 64)
+			if err != nil {
+				report(err)
+				return dst, nil
+			}
+			answer, length := cli.enc.EncodeUint64Array(n)
+			return append(dst, answer[:length]...), nil
+		})
+	case decode:
+		return processLine(dst, src, func(dst, in []byte) ([]byte, error) {
+			return cli.enc.AppendDecode(dst, cli.dropGarbage(in))
+		})
+	default:
+		return processLine(dst, src, func(dst, in []byte) ([]byte, error) {
+			return cli.enc.AppendEncode(dst, in), nil
+		})
+	}
+}
+
+// appendWrapped appends line to dst broken into lines of width characters.
+// Every full line continues on the next one, so a line whose length is a
+// multiple of width ends with an empty line.
+func appendWrapped(dst, line []byte, width int) []byte {
+	for len(line) >= width {
+		dst = append(dst, line[:width]...)
+		dst = append(dst, 0x0a)
+		line = line[width:]
+	}
+	dst = append(dst, line...)
+	return append(dst, 0x0a)
+}
+
+// garbageTable marks every byte outside the alphabet of enc.
+func garbageTable(enc *base62.Encoding) *[256]bool {
+	var garbage [256]bool
+	for i := range garbage {
+		garbage[i] = true
+	}
+	for _, c := range enc.AlphabetBytes() {
+		garbage[c] = false
+	}
+	return &garbage
+}
+
+// dropGarbage removes the garbage bytes of src in place, whitespace
+// included, as processLine only passes single values.
+func (cli *app) dropGarbage(src []byte) []byte {
+	if cli.garbage == nil {
+		return src
+	}
+	dst := src[:0]
+	for _, c := range src {
+		if !cli.garbage[c] {
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// processLine appends to dst the line src with every whitespace separated
+// word replaced by what f appends for it, the whitespace kept as is.
+func processLine(dst, src []byte, f func(dst, in []byte) ([]byte, error)) ([]byte, error) {
 	var i, j int
-	var res []byte
+	res := dst
+	var err error
 	for j < len(src) {
 		j = bytes.IndexFunc(src[i:], unicode.IsSpace)
 		if j >= 0 {
@@ -137,11 +348,10 @@ func processLine(src []byte, f func([]byte) ([]byte, error)) ([]byte, error) {
 		} else {
 			j = len(src)
 		}
-		got, err := f(src[i:j])
+		res, err = f(res, src[i:j])
 		if err != nil {
 			return nil, err
 		}
-		res = append(res, got...)
 		if j == len(src) {
 			break
 		}
@@ -158,5 +368,3 @@ func processLine(src []byte, f func([]byte) ([]byte, error)) ([]byte, error) {
 	}
 	return res, nil
 }
-
-
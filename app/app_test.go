@@ -0,0 +1,361 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package app
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/schwid/base62"
+)
+
+func newTestApp(in string) (*app, *bytes.Buffer, *bytes.Buffer) {
+	var out, errOut bytes.Buffer
+	return &app{
+		name:      "base62",
+		inStream:  bytes.NewBufferString(in),
+		outStream: &out,
+		errStream: &errOut,
+	}, &out, &errOut
+}
+
+func TestOutputDir(t *testing.T) {
+	inDir, outDir, backDir := t.TempDir(), t.TempDir(), t.TempDir()
+	inputs := map[string]string{
+		"a.txt": "hello world\n",
+		"b.txt": "first line\nsecond line\n",
+		"c":     "\n",
+	}
+	var names []string
+	for name, content := range inputs {
+		path := filepath.Join(inDir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, path)
+	}
+
+	cli, out, _ := newTestApp("")
+	if err := cli.run(append([]string{"-O", outDir}, names...)); err != nil {
+		t.Fatalf("run(-O) error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("run(-O) wrote %q to the output stream, want nothing", out)
+	}
+	var encoded []string
+	for name, content := range inputs {
+		got, err := ioutil.ReadFile(filepath.Join(outDir, name+".b62"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want bytes.Buffer
+		for _, line := range bytes.SplitAfter([]byte(content), []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			want.WriteString(processTestLine(t, bytes.TrimSuffix(line, []byte("\n"))) + "\n")
+		}
+		if string(got) != want.String() {
+			t.Errorf("output of %s = %q, want %q", name, got, want.String())
+		}
+		encoded = append(encoded, filepath.Join(outDir, name+".b62"))
+	}
+
+	// decoding strips the extension again
+	cli, _, _ = newTestApp("")
+	if err := cli.run(append([]string{"-D", "--output-dir", backDir}, encoded...)); err != nil {
+		t.Fatalf("run(-D -O) error = %v", err)
+	}
+	for name, content := range inputs {
+		got, err := ioutil.ReadFile(filepath.Join(backDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != content {
+			t.Errorf("decoded %s = %q, want %q", name, got, content)
+		}
+	}
+}
+
+func processTestLine(t *testing.T, line []byte) string {
+	got, err := processLine(nil, line, func(dst, in []byte) ([]byte, error) {
+		return base62.StdEncoding.AppendEncode(dst, in), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(got)
+}
+
+func TestOutputDirErrors(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "in.txt")
+	if err := ioutil.WriteFile(input, []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-O", dir},
+		{"-O", dir, "-o", filepath.Join(dir, "out"), input},
+		{"-O", filepath.Join(dir, "missing"), input},
+	} {
+		cli, _, _ := newTestApp("")
+		if err := cli.run(args); err == nil {
+			t.Errorf("run(%q) = nil, want error", args)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out")); !os.IsNotExist(err) {
+		t.Errorf("run with --output and --output-dir created the output file")
+	}
+
+	// an input that cannot be opened leaves no output file
+	outDir := t.TempDir()
+	cli, _, _ := newTestApp("")
+	if err := cli.run([]string{"-O", outDir, filepath.Join(dir, "missing.txt")}); err == nil {
+		t.Errorf("run(-O) of a missing input = nil, want error")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "missing.txt.b62")); !os.IsNotExist(err) {
+		t.Errorf("run(-O) of a missing input created its output file")
+	}
+
+	// inputs with the same base name would overwrite each other
+	other := filepath.Join(t.TempDir(), "in.txt")
+	if err := ioutil.WriteFile(other, []byte("y\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cli, _, _ = newTestApp("")
+	if err := cli.run([]string{"-O", outDir, input, other}); err == nil {
+		t.Errorf("run(-O) of two inputs named in.txt = nil, want error")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "in.txt.b62")); !os.IsNotExist(err) {
+		t.Errorf("run(-O) of two inputs named in.txt wrote an output file")
+	}
+
+	// the output of one input is another input
+	encoded := input + ".b62"
+	if err := ioutil.WriteFile(encoded, []byte("keep\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cli, _, _ = newTestApp("")
+	if err := cli.run([]string{"-O", dir, input, encoded}); err == nil {
+		t.Errorf("run(-O) writing over an input = nil, want error")
+	}
+	if got, err := ioutil.ReadFile(encoded); err != nil || string(got) != "keep\n" {
+		t.Errorf("run(-O) writing over an input changed it to %q, %v", got, err)
+	}
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(dir, link); err == nil {
+		cli, _, _ = newTestApp("")
+		if err := cli.run([]string{"-O", link, input, encoded}); err == nil {
+			t.Errorf("run(-O) writing over an input through a symlink = nil, want error")
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	input := "wrapped base62 output\n\n" + strings.Repeat("x", 60) + " and a few words\nshort\n"
+	cli, out, _ := newTestApp(input)
+	if err := cli.run(nil); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	unwrapped := out.String()
+
+	cli, out, _ = newTestApp(input)
+	if err := cli.run([]string{"-w", "20"}); err != nil {
+		t.Fatalf("run(-w 20) error = %v", err)
+	}
+	encoded := out.String()
+	var joined strings.Builder
+	for _, line := range strings.SplitAfter(encoded, "\n") {
+		if len(line) > 21 {
+			t.Errorf("line %q is longer than 20 characters", line)
+		}
+		if len(line) == 21 {
+			line = line[:20]
+		}
+		joined.WriteString(line)
+	}
+	if joined.String() != unwrapped {
+		t.Errorf("run(-w 20) = %q, want %q with line breaks after full lines", encoded, unwrapped)
+	}
+
+	cli, out, _ = newTestApp(encoded)
+	if err := cli.run([]string{"-D", "-w", "20"}); err != nil {
+		t.Fatalf("run(-D -w 20) error = %v", err)
+	}
+	if out.String() != input {
+		t.Errorf("run(-D -w 20) = %q, want %q", out.String(), input)
+	}
+
+	// an encoded line of exactly the width is followed by an empty line
+	line := processTestLine(t, []byte("abc"))
+	cli, out, _ = newTestApp("abc\n")
+	if err := cli.run([]string{"-w", strconv.Itoa(len(line))}); err != nil || out.String() != line+"\n\n" {
+		t.Errorf("run(-w %d) = %q, %v, want %q", len(line), out.String(), err, line+"\n\n")
+	}
+
+	cli, _, _ = newTestApp("x")
+	if err := cli.run([]string{"-w", "-1"}); err == nil {
+		t.Errorf("run(-w -1) = nil, want error")
+	}
+	cli, _, _ = newTestApp("abc?\n")
+	if err := cli.run([]string{"-D", "-w", "76"}); err == nil {
+		t.Errorf("run(-D -w 76) of invalid input = nil, want error")
+	}
+}
+
+func TestIgnoreGarbage(t *testing.T) {
+	hello := base62.StdEncoding.EncodeToString([]byte("hello"))
+	world := base62.StdEncoding.EncodeToString([]byte("world"))
+	input := `"` + hello + `", ` + world[:3] + "-" + world[3:] + ".\n"
+
+	cli, out, errOut := newTestApp(input)
+	if err := cli.run([]string{"-D"}); err == nil {
+		t.Errorf("run(-D) of garbage = nil, want error")
+	}
+	if errOut.Len() == 0 {
+		t.Errorf("run(-D) of garbage printed no error")
+	}
+
+	cli, out, _ = newTestApp(input)
+	if err := cli.run([]string{"-D", "--ignore-garbage"}); err != nil {
+		t.Fatalf("run(-D -g) error = %v", err)
+	}
+	if got := out.String(); got != "hello world\n" {
+		t.Errorf("run(-D -g) = %q, want %q", got, "hello world\n")
+	}
+
+	// wrapped lines are joined before the garbage is dropped
+	encoded := processTestLine(t, []byte("hello,world"))
+	cli, out, _ = newTestApp(`"` + encoded[:4] + "\n" + encoded[4:] + "\"\n")
+	if err := cli.run([]string{"-D", "-g", "-w", "5"}); err != nil {
+		t.Fatalf("run(-D -g -w 5) error = %v", err)
+	}
+	if got := out.String(); got != "hello,world\n" {
+		t.Errorf("run(-D -g -w 5) = %q, want %q", got, "hello,world\n")
+	}
+}
+
+func TestUint(t *testing.T) {
+	cli, out, errOut := newTestApp("1000000 0\n18446744073709551615\n18446744073709551616\nabc\n62\n")
+	if err := cli.run([]string{"--uint"}); err == nil {
+		t.Errorf("run(--uint) with invalid numbers = nil, want error")
+	}
+	// lines of invalid numbers only come out empty
+	if got, want := out.String(), "4c92 0\nlYGhA16ahyf\n\n\n10\n"; got != want {
+		t.Errorf("run(--uint) = %q, want %q", got, want)
+	}
+	if got := strings.Count(errOut.String(), "\n"); got != 2 {
+		t.Errorf("run(--uint) reported %d errors, want 2: %q", got, errOut.String())
+	}
+
+	cli, out, errOut = newTestApp("4c92 0\nlYGhA16ahyg\n10\n")
+	if err := cli.run([]string{"-D", "-u"}); err == nil {
+		t.Errorf("run(-D -u) with an overflow = nil, want error")
+	}
+	if got, want := out.String(), "1000000 0\n\n62\n"; got != want {
+		t.Errorf("run(-D -u) = %q, want %q", got, want)
+	}
+	if got := strings.Count(errOut.String(), "\n"); got != 1 {
+		t.Errorf("run(-D -u) reported %d errors, want 1: %q", got, errOut.String())
+	}
+
+	// every invalid token is reported, the valid ones are still converted
+	cli, out, errOut = newTestApp("1 abc 3 x\n")
+	if err := cli.run([]string{"-u"}); err == nil {
+		t.Errorf("run(-u) of a line with invalid tokens = nil, want error")
+	}
+	if got, want := out.String(), "1  3 \n"; got != want {
+		t.Errorf("run(-u) = %q, want %q", got, want)
+	}
+	if got := errOut.String(); strings.Count(got, "\n") != 2 || !strings.Contains(got, "abc") || !strings.Contains(got, `"x"`) {
+		t.Errorf("run(-u) reported %q, want errors for abc and x", got)
+	}
+	cli, out, errOut = newTestApp("1 ab? 3 x!\n")
+	if err := cli.run([]string{"-D", "-u"}); err == nil {
+		t.Errorf("run(-D -u) of a line with invalid tokens = nil, want error")
+	}
+	if got, want := out.String(), "1  3 \n"; got != want {
+		t.Errorf("run(-D -u) = %q, want %q", got, want)
+	}
+	if got := strings.Count(errOut.String(), "\n"); got != 2 {
+		t.Errorf("run(-D -u) reported %d errors, want 2: %q", got, errOut.String())
+	}
+
+	cli, _, _ = newTestApp("1\n")
+	if err := cli.run([]string{"-u", "-w", "10"}); err == nil {
+		t.Errorf("run(-u -w 10) = nil, want error")
+	}
+}
+
+func TestAlphabet(t *testing.T) {
+	const gmp = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	cli, out, _ := newTestApp("hello\n")
+	if err := cli.run([]string{"--alphabet", gmp}); err != nil {
+		t.Fatalf("run(--alphabet) error = %v", err)
+	}
+	want := base62.GMPEncoding.EncodeToString([]byte("hello")) + "\n"
+	if out.String() != want {
+		t.Errorf("run(--alphabet) = %q, want %q", out.String(), want)
+	}
+
+	cli, out, _ = newTestApp(want)
+	if err := cli.run([]string{"-D", "-a", gmp}); err != nil || out.String() != "hello\n" {
+		t.Errorf("run(-D -a) = %q, %v, want %q", out.String(), err, "hello\n")
+	}
+
+	for _, alphabet := range []string{gmp[1:], gmp[:61] + "0"} {
+		cli, out, _ = newTestApp("hello\n")
+		if err := cli.run([]string{"-a", alphabet}); err == nil || out.Len() != 0 {
+			t.Errorf("run(-a %q) = %q, %v, want error and no output", alphabet, out.String(), err)
+		}
+	}
+}
+
+func TestProcessLine(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"", ""},
+		{"abc", "qMin"},
+		{"abc 11", "qMin 3h7"},
+		{"  abc\t11  ", "  qMin\t3h7  "},
+	}
+	for _, tt := range tests {
+		if got := processTestLine(t, []byte(tt.in)); got != tt.out {
+			t.Errorf("processLine(%q) = %q, want %q", tt.in, got, tt.out)
+		}
+	}
+	dst := []byte("kept ")
+	got, err := processLine(dst, []byte("abc"), func(dst, in []byte) ([]byte, error) {
+		return base62.StdEncoding.AppendEncode(dst, in), nil
+	})
+	if err != nil || string(got) != "kept qMin" {
+		t.Errorf("processLine(dst, abc) = %q, %v, want %q", got, err, "kept qMin")
+	}
+}
+
+func BenchmarkRunInternal_10KLines(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 10000; i++ {
+		sb.WriteString("line of input number ")
+		sb.WriteString(strings.Repeat("x", i%40))
+		sb.WriteString("\n")
+	}
+	input := sb.String()
+	cli := &app{enc: base62.StdEncoding, errStream: ioutil.Discard}
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := cli.runInternal(false, strings.NewReader(input), ioutil.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
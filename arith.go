@@ -0,0 +1,22 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// Concat returns the encoding of the value decode(a)*R^len(b) + decode(b),
+// R the radix, that is a shifted left by the digits of b. In a positional
+// system that is the concatenation of the two strings, so no arithmetic is needed; both inputs
+// are only checked to be made of alphabet characters.
+func (e *Encoding) Concat(a, b string) (string, error) {
+	for _, s := range []string{a, b} {
+		for i := 0; i < len(s); i++ {
+			if e.digitMap()[s[i]] == 255 {
+				return "", fmt.Errorf("invalid character %q in base62 string %q", s[i], s)
+			}
+		}
+	}
+	return a + b, nil
+}
@@ -0,0 +1,43 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"math/big"
+	"testing"
+)
+
+var concatTests = []struct{ a, b string }{
+	{"", ""},
+	{"1", ""},
+	{"", "1"},
+	{"1", "0"},
+	{"Z", "00"},
+	{"hello", "World"},
+	{"00a", "0b"},
+	{"lYGhA16ahyf", "lYGhA16ahyf"},
+}
+
+func TestConcat(t *testing.T) {
+	for _, tt := range concatTests {
+		s, err := base62.StdEncoding.Concat(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Concat(%q, %q) error = %v", tt.a, tt.b, err)
+		}
+		a, _ := base62.StdEncoding.DecodeToBigInt(tt.a)
+		b, _ := base62.StdEncoding.DecodeToBigInt(tt.b)
+		want := new(big.Int).Exp(big.NewInt(62), big.NewInt(int64(len(tt.b))), nil)
+		want.Mul(want, a).Add(want, b)
+		if got, err := base62.StdEncoding.DecodeToBigInt(s); err != nil || got.Cmp(want) != 0 {
+			t.Errorf("Concat(%q, %q) = %q decoding to %v, want %v", tt.a, tt.b, s, got, want)
+		}
+	}
+	for _, tt := range [][2]string{{"a?", "b"}, {"a", "b c"}} {
+		if s, err := base62.StdEncoding.Concat(tt[0], tt[1]); err == nil {
+			t.Errorf("Concat(%q, %q) = %q, want error", tt[0], tt[1], s)
+		}
+	}
+}
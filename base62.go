@@ -6,21 +6,78 @@ package base62
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"math/bits"
+	"strings"
+	"time"
 )
 
 const (
 	radix = uint64(62)
+	// radix10 is 62^10, the largest power of the radix that fits in uint64.
+	radix10 = radix * radix * radix * radix * radix * radix * radix * radix * radix * radix
+	// maxUint64Len is the number of digits needed for math.MaxUint64.
+	maxUint64Len = 11
 )
 
+// An Encoding is a radix 62 encoding defined by a 62 character alphabet.
+// An Encoding is immutable after construction: the With methods return
+// modified copies and no method keeps state between calls, so a single
+// Encoding, StdEncoding included, is safe for concurrent use by multiple
+// goroutines. The encoders and decoders created by NewEncoder and NewDecoder
+// are not.
 type Encoding struct {
 	alphabet  [62]byte
 	decodeMap [256]byte
 	alphabetIdx0 byte
+	ocr map[byte]byte
+	canonical bool
+	now func() time.Time
+	caseHint bool
+	separators bool
+	// maxInputLen is the TryEncodeToString limit, 0 for the default
+	maxInputLen int
+	// checksumLen is the EncodeCheck checksum length, 0 for the default
+	checksumLen int
+	// complement maps each character to its complement, nil if unset
+	complement *[256]byte
+	// ext holds the alphabet beyond 62 characters, nil unless extended
+	ext *extension
 }
 
-// New creates a new base62 encoding.
+// New creates a new base62 encoding. It panics if the alphabet is malformed,
+// see NewEncoding.
 func New(alphabet []byte) *Encoding {
+	enc, err := NewEncoding(string(alphabet))
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// NewEncoding creates a new base62 encoding from an alphabet of exactly 62
+// distinct bytes, none of them NUL, and returns an error otherwise.
+func NewEncoding(alphabet string) (*Encoding, error) {
+	if len(alphabet) != int(radix) {
+		return nil, fmt.Errorf("invalid base62 alphabet %q of length %d, want %d", alphabet, len(alphabet), int(radix))
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c == 0 {
+			return nil, fmt.Errorf("invalid base62 alphabet %q, NUL byte at %d", alphabet, i)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("invalid base62 alphabet %q, duplicate character %q at %d", alphabet, c, i)
+		}
+		seen[c] = true
+	}
+	return newEncoding([]byte(alphabet)), nil
+}
+
+// newEncoding creates an encoding without validating the alphabet.
+func newEncoding(alphabet []byte) *Encoding {
 	enc := &Encoding{}
 	copy(enc.alphabet[:], alphabet)
 	for i := range enc.decodeMap {
@@ -33,8 +90,79 @@ func New(alphabet []byte) *Encoding {
 	return enc
 }
 
+// Alphabet returns the alphabet of the encoding, of Radix characters.
+func (e *Encoding) Alphabet() string {
+	if e.ext != nil {
+		return string(e.ext.alphabet)
+	}
+	return string(e.alphabet[:])
+}
+
+// Clone returns an independent copy of the encoding, options included.
+// Encodings are immutable, so this is only needed to get a distinct
+// pointer, for instance as a map key apart from StdEncoding.
+func (e *Encoding) Clone() *Encoding {
+	c := *e
+	return &c
+}
+
+// AlphabetBytes returns a copy of the encoding alphabet.
+func (e *Encoding) AlphabetBytes() []byte {
+	if e.ext != nil {
+		return append([]byte(nil), e.ext.alphabet...)
+	}
+	alphabet := make([]byte, len(e.alphabet))
+	copy(alphabet, e.alphabet[:])
+	return alphabet
+}
+
+// WithCanonicalEncode returns a copy of the encoding that strips leading
+// zero bytes before encoding, so that inputs of the same numeric value such
+// as {0, 0, 5} and {5} share one encoding. The encoding is then numeric
+// rather than byte-preserving: decoding returns the minimal byte slice and
+// the original leading zero bytes are lost.
+func (e *Encoding) WithCanonicalEncode() *Encoding {
+	c := *e
+	c.canonical = true
+	return &c
+}
+
+// WithDigitSeparators returns a copy of the encoding whose DecodeToUint64
+// accepts underscores between digits as grouping, like Go numeric literals:
+// "1_000" decodes as "1000". An underscore at the start or the end, or next
+// to another underscore, is an error. It panics if the alphabet contains
+// an underscore.
+func (e *Encoding) WithDigitSeparators() *Encoding {
+	if e.decodeMap['_'] != 255 {
+		panic("base62: digit separator '_' is contained in the alphabet")
+	}
+	c := *e
+	c.separators = true
+	return &c
+}
+
+// stripSeparators removes the underscore digit separators of s.
+func stripSeparators(s string) (string, error) {
+	if strings.IndexByte(s, '_') < 0 {
+		return s, nil
+	}
+	if s[0] == '_' || s[len(s)-1] == '_' || strings.Contains(s, "__") {
+		return "", fmt.Errorf("misplaced digit separator in decoding a base62 string %q", s)
+	}
+	return strings.ReplaceAll(s, "_", ""), nil
+}
+
+// StdEncoding is the standard base62 encoding, digits then lowercase then
+// uppercase letters. Its digit order does not follow byte order, so
+// encoded strings do not sort like the values they encode.
 var StdEncoding = New([]byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"))
 
+// GMPEncoding is the base62 encoding of GMP and most other big number
+// libraries, digits then uppercase then lowercase letters. That is ASCII
+// order, so fixed-width encodings, such as those of EncodeInt64Ordered or
+// zero-padded ones, sort lexicographically like the values they encode.
+var GMPEncoding = New([]byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"))
+
 
 var bigRadix = [...]*big.Int{
 	big.NewInt(0),
@@ -54,118 +182,516 @@ var bigRadix10 = big.NewInt(62 * 62 * 62 * 62 * 62 * 62 * 62 * 62 * 62 * 62) //
 
 // Decode decodes a modified base62 string to a byte slice.
 func (e * Encoding) DecodeString(b string) ([]byte, error) {
-	answer := big.NewInt(0)
-	tmp := new(big.Int)
+	limbs, err := e.decodeLimbs(b)
+	if err != nil {
+		return nil, e.caseHintError(b, err, func(e *Encoding, s string) error {
+			_, err := e.DecodeString(s)
+			return err
+		})
+	}
 
-	for t := b; len(t) > 0; {
-		n := len(t)
-		if n > 10 {
-			n = 10
+	var numZeros int
+	for numZeros = 0; numZeros < len(b); numZeros++ {
+		if b[numZeros] != e.alphabetIdx0 {
+			break
 		}
+	}
+	val := make([]byte, numZeros+limbsByteLen(limbs))
+	putLimbs(val[numZeros:], limbs)
+
+	return val, nil
+}
+
+// radixPow holds the powers of the radix up to radix10.
+var radixPow = [11]uint64{1, radix, radix * radix, radix * radix * radix,
+	radix * radix * radix * radix, radix * radix * radix * radix * radix,
+	radix10 / radix / radix / radix / radix, radix10 / radix / radix / radix,
+	radix10 / radix / radix, radix10 / radix, radix10}
+
+// decodeLimbs decodes the numeric value of s into 64-bit limbs, least
+// significant first, with no zero limb at the top.
+//
+// Groups of ten digits are folded in with multiply-add passes over the
+// limbs. Four groups share a pass, each one consuming the limbs of the
+// previous one as they come, so that the four carry chains, which do not
+// depend on each other, overlap in the processor.
+func (e *Encoding) decodeLimbs(s string) ([]uint64, error) {
+	return e.decodeLimbsTo(nil, nil, s)
+}
 
-		total := uint64(0)
-		for _, v := range t[:n] {
-			c := e.decodeMap[v]
-			if c == 255 {
-				return nil, fmt.Errorf("invalid character '%c' in decoding a base62 string '%s'", c, b)
+// decodeLimbsTo is decodeLimbs reusing the limbs of *scratch, where the
+// possibly grown buffer is stored back, and adding the work done to stats.
+// A nil scratch allocates and a nil stats counts nothing.
+func (e *Encoding) decodeLimbsTo(scratch *[]uint64, stats *DecodeStats, s string) ([]uint64, error) {
+	if e.ext != nil {
+		return e.decodeLimbsExt(s)
+	}
+	limbs := limbBuffer(scratch, len(s)*6/64+5)[:0]
+	for t := s; len(t) > 0; {
+		// absent groups multiply by one and add zero
+		m := [4]uint64{1, 1, 1, 1}
+		var g [4]uint64
+		groups := 0
+		for ; groups < len(m) && len(t) > 0; groups++ {
+			j := groups
+			n := len(t)
+			if n > 10 {
+				n = 10
+			}
+			for _, v := range []byte(t[:n]) {
+				c := e.decodeMap[v]
+				if c == 255 {
+					return nil, fmt.Errorf("invalid character %q in decoding a base62 string %q", v, s)
+				}
+				g[j] = g[j]*radix + uint64(c)
 			}
-			total = total*62 + uint64(c)
+			m[j] = radixPow[n]
+			t = t[n:]
+		}
+
+		// room for the carries, each multiplier is below 2^60
+		limbs = append(limbs, 0, 0, 0, 0)
+		c0, c1, c2, c3 := g[0], g[1], g[2], g[3]
+		for i, l := range limbs {
+			l, c0 = mulAdd(l, m[0], c0)
+			l, c1 = mulAdd(l, m[1], c1)
+			l, c2 = mulAdd(l, m[2], c2)
+			limbs[i], c3 = mulAdd(l, m[3], c3)
+		}
+		if stats != nil {
+			stats.Groups += groups
+			stats.Passes++
+			stats.MulAdds += len(m) * len(limbs)
 		}
+		for len(limbs) > 0 && limbs[len(limbs)-1] == 0 {
+			limbs = limbs[:len(limbs)-1]
+		}
+	}
+	if scratch != nil {
+		*scratch = limbs[:0]
+	}
+	return limbs, nil
+}
+
+// limbBuffer returns n zeroed limbs, from *scratch when its capacity
+// suffices. A larger buffer replaces *scratch for the next calls; a nil
+// scratch always allocates.
+func limbBuffer(scratch *[]uint64, n int) []uint64 {
+	if scratch == nil {
+		return make([]uint64, n)
+	}
+	if cap(*scratch) < n {
+		*scratch = make([]uint64, n)
+	}
+	limbs := (*scratch)[:n]
+	for i := range limbs {
+		limbs[i] = 0
+	}
+	return limbs
+}
+
+// mulAdd returns the low and high words of x*m + c.
+func mulAdd(x, m, c uint64) (lo, hi uint64) {
+	hi, lo = bits.Mul64(x, m)
+	lo, carry := bits.Add64(lo, c, 0)
+	return lo, hi + carry
+}
 
-		answer.Mul(answer, bigRadix[n])
-		tmp.SetUint64(total)
-		answer.Add(answer, tmp)
+// limbsByteLen returns the number of bytes of the value of limbs, without
+// leading zero bytes.
+func limbsByteLen(limbs []uint64) int {
+	if len(limbs) == 0 {
+		return 0
+	}
+	return (len(limbs)-1)*8 + (bits.Len64(limbs[len(limbs)-1])+7)/8
+}
 
-		t = t[n:]
+// putLimbs writes the value of limbs to dst in big endian order, filling
+// dst from its end.
+func putLimbs(dst []byte, limbs []uint64) {
+	i := len(dst)
+	for _, l := range limbs {
+		for k := 0; k < 8 && i > 0; k++ {
+			i--
+			dst[i] = byte(l)
+			l >>= 8
+		}
 	}
+}
 
-	tmpval := answer.Bytes()
+// Decode decodes src using the encoding e, writing the bytes to dst, and
+// returns the number of bytes written. Each leading zero digit of src becomes
+// a zero byte at the start of dst and bytes past the result are untouched.
+// It panics if dst is too short for the result; DecodedLen(len(src)) bytes
+// always suffice.
+func (e *Encoding) Decode(dst, src []byte) (int, error) {
+	limbs, err := e.decodeLimbs(string(src))
+	if err != nil {
+		return 0, e.caseHintError(string(src), err, func(e *Encoding, s string) error {
+			_, err := e.decodeLimbs(s)
+			return err
+		})
+	}
 
 	var numZeros int
-	for numZeros = 0; numZeros < len(b); numZeros++ {
-		if b[numZeros] != e.alphabetIdx0 {
-			break
+	for numZeros < len(src) && src[numZeros] == e.alphabetIdx0 {
+		numZeros++
+	}
+	n := numZeros + limbsByteLen(limbs)
+	if len(dst) < n {
+		panic(fmt.Sprintf("base62: output buffer of %d bytes is too small, need %d", len(dst), n))
+	}
+	for i := range dst[:numZeros] {
+		dst[i] = 0
+	}
+	putLimbs(dst[numZeros:n], limbs)
+	return n, nil
+}
+
+// DecodeInPlace decodes the encoded characters in buf over buf itself and
+// returns the decoded length n, the result being buf[:n]. The decoding is
+// never longer than its input, see DecodedLen, and the whole input is read
+// before the first byte is written. On error buf is left unchanged.
+func (e *Encoding) DecodeInPlace(buf []byte) (int, error) {
+	return e.Decode(buf, buf)
+}
+
+// DecodeToBigInt decodes the base62 string to its numeric value. The
+// returned integer is built straight from the limbs of the decoding,
+// freshly allocated and owned by the caller, so no byte slice round trip is
+// needed to continue arithmetic on it. Leading zero digits do not change the
+// value. It is the inverse of EncodeBigInt.
+func (e *Encoding) DecodeToBigInt(b string) (*big.Int, error) {
+	if e.ext != nil {
+		return e.decodeBigIntExt(b)
+	}
+	limbs, err := e.decodeLimbs(b)
+	if err != nil {
+		return nil, err
+	}
+	words := make([]big.Word, 0, len(limbs)*64/bits.UintSize)
+	for _, l := range limbs {
+		for k := 0; k < 64; k += bits.UintSize {
+			words = append(words, big.Word(l>>uint(k)))
 		}
 	}
-	flen := numZeros + len(tmpval)
-	val := make([]byte, flen)
-	copy(val[numZeros:], tmpval)
+	return new(big.Int).SetBits(words), nil
+}
 
-	return val, nil
+// EncodeBigInt encodes the numeric value of x, with no leading zero digit
+// except for zero itself, which encodes as the single zero digit. Only
+// non-negative values have an encoding; EncodeBigInt panics if x < 0.
+func (e *Encoding) EncodeBigInt(x *big.Int) string {
+	switch x.Sign() {
+	case -1:
+		panic("base62: EncodeBigInt of a negative value " + x.String())
+	case 0:
+		return string(e.alphabetIdx0)
+	}
+	return e.encode(x.Bytes())
 }
 
 // Encode encodes a byte slice to a modified base62 string.
 func  (e * Encoding) EncodeToString(b []byte) string {
-	x := new(big.Int)
-	x.SetBytes(b)
-
-	maxlen := int(float64(len(b))*1.5) + 1
-	answer := make([]byte, 0, maxlen)
-	mod := new(big.Int)
-	for x.Sign() > 0 {
-		x.DivMod(x, bigRadix10, mod)
-		if x.Sign() == 0 {
-			// When x = 0, we need to ensure we don't add any extra zeros.
-			m := mod.Int64()
-			for m > 0 {
-				answer = append(answer, e.alphabet[m%62])
-				m /= 62
-			}
-		} else {
-			m := mod.Int64()
-			for i := 0; i < 10; i++ {
-				answer = append(answer, e.alphabet[m%62])
-				m /= 62
-			}
-		}
+	if e.canonical {
+		b = trimLeadingZeros(b)
 	}
+	return e.encode(b)
+}
 
-	// leading zero bytes
-	for _, i := range b {
-		if i != 0 {
-			break
-		}
-		answer = append(answer, e.alphabetIdx0)
+// encode encodes b keeping its leading zero bytes regardless of options.
+func (e *Encoding) encode(b []byte) string {
+	answer := make([]byte, e.EncodedLen(len(b)))
+	n := e.encodeTo(answer, b)
+	return string(answer[:n])
+}
+
+// Encode encodes src using the encoding e, writing characters to dst,
+// and returns the number of bytes written. It panics if dst is shorter than
+// EncodedLen(len(src)); the output may be shorter than that, so only
+// dst[:n] holds the encoding.
+func (e *Encoding) Encode(dst, src []byte) int {
+	if e.canonical {
+		src = trimLeadingZeros(src)
+	}
+	return e.encodeTo(dst, src)
+}
+
+// AppendEncode appends the encoding of src to dst and returns the extended
+// buffer.
+func (e *Encoding) AppendEncode(dst, src []byte) []byte {
+	n := e.EncodedLen(len(src))
+	dst = grow(dst, n)
+	k := e.Encode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+k]
+}
+
+// AppendDecode appends the decoding of src to dst and returns the extended
+// buffer. On invalid input dst is returned unextended with the error.
+func (e *Encoding) AppendDecode(dst, src []byte) ([]byte, error) {
+	n := e.DecodedLen(len(src))
+	dst = grow(dst, n)
+	k, err := e.Decode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+k], err
+}
+
+// grow returns dst with room for n more bytes.
+func grow(dst []byte, n int) []byte {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+	buf := make([]byte, len(dst), len(dst)+n)
+	copy(buf, dst)
+	return buf
+}
+
+// encodeTo is Encode without the canonical option.
+func (e *Encoding) encodeTo(dst, src []byte) int {
+	return e.encodeToScratch(dst, src, nil)
+}
+
+// encodeToScratch is encodeTo reusing the limbs of *scratch, see
+// appendDigitsLE.
+func (e *Encoding) encodeToScratch(dst, src []byte, scratch *[]uint64) int {
+	if len(dst) < e.EncodedLen(len(src)) {
+		panic(fmt.Sprintf("base62: output buffer of %d bytes is too small, need %d", len(dst), e.EncodedLen(len(src))))
+	}
+	if e.ext != nil {
+		return e.encodeToExt(dst, src)
 	}
+	// the capacity bound keeps append inside dst
+	answer := appendDigitsLE(dst[:0:len(dst)], src, scratch)
 
 	// reverse
 	alen := len(answer)
 	for i := 0; i < alen/2; i++ {
-		answer[i], answer[alen-1-i] = answer[alen-1-i], answer[i]
+		answer[i], answer[alen-1-i] = e.alphabet[answer[alen-1-i]], e.alphabet[answer[i]]
+	}
+	if alen%2 == 1 {
+		answer[alen/2] = e.alphabet[answer[alen/2]]
 	}
 
-	return string(answer)
+	return alen
 }
 
-// EncodeUint64 encodes the unsigned integer.
-func (e *Encoding) EncodeUint64(n uint64) string {
-	if n == 0 {
-		return string(e.alphabetIdx0)
+// EncodeToDigitsLE returns the digit values of the encoding of b, least
+// significant first, without mapping them through the alphabet. Reversing
+// the result and mapping each digit gives EncodeToString(b).
+func (e *Encoding) EncodeToDigitsLE(b []byte) []byte {
+	if e.canonical {
+		b = trimLeadingZeros(b)
+	}
+	return appendDigitsLE(make([]byte, 0, e.EncodedLen(len(b))), b, nil)
+}
+
+// appendDigitsLE appends the base62 digit values of b to dst, least
+// significant first, with one zero digit for each leading zero byte.
+//
+// The value is held in big endian 64-bit limbs and repeatedly divided in
+// place by 62^10, the largest power of 62 below 2^64, so that each pass
+// over the limbs yields ten digits. With 32-bit limbs the remainder times
+// 2^32 would not fit in a word for this divisor. The limbs are taken from *scratch,
+// see limbBuffer, or allocated when scratch is nil.
+func appendDigitsLE(dst []byte, b []byte, scratch *[]uint64) []byte {
+	var zeros int
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	rest := b[zeros:]
+	if len(rest) <= 16 {
+		return appendZerosLE(appendDigits128LE(dst, rest), zeros)
+	}
+
+	limbs := limbBuffer(scratch, (len(rest)+7)/8)
+	for k, c := range rest {
+		i := len(limbs) - 1 - (len(rest)-1-k)/8
+		limbs[i] = limbs[i]<<8 | uint64(c)
+	}
+
+	// Four divisions run per pass, each one consuming the quotient limbs of
+	// the previous one as they come. The four remainder chains do not
+	// depend on each other, so the processor overlaps them.
+	for len(limbs) > 0 {
+		var r0, r1, r2, r3 uint64
+		for i, w := range limbs {
+			w, r0 = bits.Div64(r0, w, radix10)
+			w, r1 = bits.Div64(r1, w, radix10)
+			w, r2 = bits.Div64(r2, w, radix10)
+			limbs[i], r3 = bits.Div64(r3, w, radix10)
+		}
+		for len(limbs) > 0 && limbs[0] == 0 {
+			limbs = limbs[1:]
+		}
+		// a quotient is zero when both the next remainder and the next
+		// quotient are
+		more3 := len(limbs) > 0
+		more2 := more3 || r3 != 0
+		more1 := more2 || r2 != 0
+		more0 := more1 || r1 != 0
+		dst = appendGroupLE(dst, r0, more0)
+		if !more0 {
+			break
+		}
+		dst = appendGroupLE(dst, r1, more1)
+		if !more1 {
+			break
+		}
+		dst = appendGroupLE(dst, r2, more2)
+		if !more2 {
+			break
+		}
+		dst = appendGroupLE(dst, r3, more3)
 	}
-	answer := make([]byte, 12)
-	i := len(answer)
-	var mod uint64
-	for n > 0 {
-		n, mod = n/radix, n%radix
-		i--
-		answer[i] = e.alphabet[mod]
+
+	// leading zero bytes
+	return appendZerosLE(dst, zeros)
+}
+
+// appendZerosLE appends the zero digits of n leading zero bytes.
+func appendZerosLE(dst []byte, n int) []byte {
+	for i := 0; i < n; i++ {
+		dst = append(dst, 0)
+	}
+	return dst
+}
+
+// appendDigits128LE is appendDigitsLE for at most 16 bytes without leading
+// zero bytes, such as UUIDs. The value fits in two 64-bit words, so no
+// limbs are allocated and each group of ten digits takes two divisions.
+func appendDigits128LE(dst []byte, b []byte) []byte {
+	var hi, lo uint64
+	for _, c := range b {
+		hi = hi<<8 | lo>>56
+		lo = lo<<8 | uint64(c)
+	}
+	for hi != 0 || lo != 0 {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, radix10)
+		lo, rem = bits.Div64(rem, lo, radix10)
+		dst = appendGroupLE(dst, rem, hi != 0 || lo != 0)
+	}
+	return dst
+}
+
+// appendGroupLE appends the digits of a remainder of the division by
+// radix10, least significant first: all ten of them when more digits follow,
+// otherwise without the padding zeros of the most significant group.
+func appendGroupLE(dst []byte, rem uint64, more bool) []byte {
+	if !more {
+		for rem > 0 {
+			dst = append(dst, byte(rem%radix))
+			rem /= radix
+		}
+		return dst
+	}
+	for i := 0; i < 10; i++ {
+		dst = append(dst, byte(rem%radix))
+		rem /= radix
+	}
+	return dst
+}
+
+// EncodedLen returns the maximum length in characters of the encoding of n
+// bytes, reached when no byte is zero: ceil(n*8/log2(62)). Leading zero
+// bytes take a single character each and never make the encoding longer.
+func (e *Encoding) EncodedLen(n int) int {
+	return int(math.Ceil(float64(n) * 8 / math.Log2(float64(e.Radix()))))
+}
+
+// EncodedLenStats returns the bounds of the length in characters of the
+// encoding of n bytes, for sizing a column of such encodings. The minimum
+// is reached by n zero bytes, one character each, or by none with the
+// canonical option, which encodes them as the empty string; any other
+// input needs at least as many characters. The maximum is EncodedLen(n),
+// reached by n 0xff bytes.
+func (e *Encoding) EncodedLenStats(n int) (min, max int) {
+	if n <= 0 {
+		return 0, 0
 	}
-	return string(answer[i:])
+	if e.canonical {
+		min = 0
+	} else {
+		min = n
+	}
+	return min, e.EncodedLen(n)
+}
+
+// DecodedLen returns the maximum length in bytes of the decoding of n
+// characters. That is n itself, reached when all characters are the zero
+// digit: each of them stands for a zero byte, while other digits carry
+// less than a byte each.
+func (e *Encoding) DecodedLen(n int) int {
+	return n
+}
+
+// EncodeUint64 encodes the unsigned integer.
+func (e *Encoding) EncodeUint64(n uint64) string {
+	answer, length := e.EncodeUint64Array(n)
+	return string(answer[:length])
 }
 
 // DecodeUint64 decodes the base62 encoded string to an unsigned integer.
+// Leading zero digits are skipped, so padded encodings of any width decode.
 func (e *Encoding) DecodeToUint64(src string) (uint64, error) {
-	var n, m uint64
+	n, err := e.decodeToUint64(src)
+	if err != nil {
+		return 0, e.caseHintError(src, err, func(e *Encoding, s string) error {
+			_, err := e.decodeToUint64(s)
+			return err
+		})
+	}
+	return n, nil
+}
+
+func (e *Encoding) decodeToUint64(src string) (uint64, error) {
+	digits := src
+	if e.separators {
+		var err error
+		if digits, err = stripSeparators(src); err != nil {
+			return 0, err
+		}
+	}
+	if e.ext != nil {
+		return e.decodeUint64Ext(src, digits)
+	}
+	// leading zero digits, as in padded encodings, do not count
+	for len(digits) > maxUint64Len && digits[0] == e.alphabetIdx0 {
+		digits = digits[1:]
+	}
+	if len(digits) > maxUint64Len {
+		return 0, fmt.Errorf("too long input in decoding a base62 string %q, at most %d characters fit in uint64", src, maxUint64Len)
+	}
+	var n uint64
 	var i byte
-	for _, c := range []byte(src) {
-		if i = e.decodeMap[c]; i < 0 {
-			return 0, fmt.Errorf("invalid character '%c' in decoding a base62 string %q", c, src)
+	for _, c := range []byte(digits) {
+		if i = e.decodeMap[c]; i == 255 {
+			return 0, fmt.Errorf("invalid character %q in decoding a base62 string %q", c, src)
 		}
-		m = n*radix + uint64(i)
-		if m < n {
+		// n*radix wraps around without necessarily getting smaller
+		if n > (math.MaxUint64-uint64(i))/radix {
 			return 0, fmt.Errorf("overflow in decoding a base62 string %q", src)
 		}
-		n = m
+		n = n*radix + uint64(i)
 	}
 	return n, nil
 }
+
+// EncodeUint32 encodes the unsigned integer, exactly as EncodeUint64 does
+// for the same value.
+func (e *Encoding) EncodeUint32(n uint32) string {
+	return e.EncodeUint64(uint64(n))
+}
+
+// DecodeToUint32 decodes the base62 encoded string to an unsigned integer
+// like DecodeToUint64, returning an overflow error when the value exceeds
+// math.MaxUint32.
+func (e *Encoding) DecodeToUint32(src string) (uint32, error) {
+	n, err := e.DecodeToUint64(src)
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxUint32 {
+		return 0, fmt.Errorf("overflow in decoding a base62 string %q", src)
+	}
+	return uint32(n), nil
+}
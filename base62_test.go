@@ -8,9 +8,13 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"github.com/schwid/base62"
 	"math"
+	"math/big"
 	"math/rand"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -155,6 +159,12 @@ func TestDecodeUint64Overflow(t *testing.T) {
 	if err == nil {
 		t.Errorf("Overflow error should occur while decoding %s but got %d.", bs, got)
 	}
+	// wrapping multiplications that do not get smaller
+	for _, src := range []string{"LygHa16AHYF", "ZZZZZZZZZZZ"} {
+		if got, err := base62.StdEncoding.DecodeToUint64(src); err == nil {
+			t.Errorf("Overflow error should occur while decoding %s but got %d.", src, got)
+		}
+	}
 	src = "aaaaaaaaaaaaaa"
 	got, err = base62.StdEncoding.DecodeToUint64(src)
 	if err == nil {
@@ -178,4 +188,613 @@ func removeLeadingZeros(b []byte) []byte {
 		return b[:1]
 	}
 	return b
-}
\ No newline at end of file
+}
+
+func TestEncodeToDigitsLE(t *testing.T) {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	for x, test := range hexTests {
+		b, _ := hex.DecodeString(test.in)
+		digits := base62.StdEncoding.EncodeToDigitsLE(b)
+		rendered := make([]byte, len(digits))
+		for i, d := range digits {
+			rendered[len(digits)-1-i] = alphabet[d]
+		}
+		if string(rendered) != test.out {
+			t.Errorf("EncodeToDigitsLE test #%d failed: got: %s want: %s", x, rendered, test.out)
+		}
+	}
+}
+
+func TestDecodeControlCharacters(t *testing.T) {
+	var controls []byte
+	for c := byte(0); c < 0x20; c++ {
+		controls = append(controls, c)
+	}
+	controls = append(controls, 0x7f)
+	for _, c := range controls {
+		src := "ab" + string([]byte{c}) + "c"
+		want := fmt.Sprintf("invalid character %q", c)
+		if res, err := base62.StdEncoding.DecodeString(src); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("DecodeString(%q) = %q, %v, want error containing %s", src, res, err, want)
+		}
+		if res, err := base62.StdEncoding.DecodeToUint64(src); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("DecodeToUint64(%q) = %d, %v, want error containing %s", src, res, err, want)
+		}
+	}
+}
+
+func TestDecodeNonASCII(t *testing.T) {
+	for _, src := range []string{"é", "abc€", "\xff"} {
+		if res, err := base62.StdEncoding.DecodeString(src); err == nil {
+			t.Errorf("DecodeString(%q) = %q, want error", src, res)
+		}
+	}
+}
+
+func TestAlphabetBytes(t *testing.T) {
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	enc := base62.New([]byte(alphabet))
+	b := enc.AlphabetBytes()
+	if string(b) != alphabet {
+		t.Fatalf("AlphabetBytes() = %s, want %s", b, alphabet)
+	}
+	for i := range b {
+		b[i] = '!'
+	}
+	if got := enc.AlphabetBytes(); string(got) != alphabet {
+		t.Errorf("AlphabetBytes() after mutating a copy = %s, want %s", got, alphabet)
+	}
+	if got := enc.EncodeUint64(61); got != "z" {
+		t.Errorf("EncodeUint64(61) after mutating a copy = %s, want z", got)
+	}
+}
+
+func TestAlphabet(t *testing.T) {
+	if got := base62.StdEncoding.Alphabet(); got != string(base62.StdEncoding.AlphabetBytes()) || len(got) != 62 {
+		t.Errorf("Alphabet() = %s, want %s", got, base62.StdEncoding.AlphabetBytes())
+	}
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	if got := base62.GMPEncoding.Alphabet(); got != alphabet {
+		t.Errorf("GMPEncoding.Alphabet() = %s, want %s", got, alphabet)
+	}
+}
+
+func TestClone(t *testing.T) {
+	c := base62.StdEncoding.Clone()
+	if c == base62.StdEncoding {
+		t.Fatalf("Clone() returned StdEncoding itself")
+	}
+	if c.Alphabet() != base62.StdEncoding.Alphabet() {
+		t.Errorf("Clone().Alphabet() = %s, want %s", c.Alphabet(), base62.StdEncoding.Alphabet())
+	}
+	for _, tt := range stringTests {
+		if got := c.EncodeToString([]byte(tt.in)); got != tt.out {
+			t.Errorf("Clone().EncodeToString(%q) = %s, want %s", tt.in, got, tt.out)
+		}
+	}
+	canonical := base62.StdEncoding.WithCanonicalEncode().Clone()
+	if got := canonical.EncodeToString([]byte{0, 5}); got != "5" {
+		t.Errorf("canonical Clone().EncodeToString({0, 5}) = %s, want 5", got)
+	}
+	// deriving from the clone leaves StdEncoding as it was
+	c.WithCanonicalEncode()
+	if got := base62.StdEncoding.EncodeToString([]byte{0, 5}); got != "05" {
+		t.Errorf("StdEncoding.EncodeToString({0, 5}) = %s, want 05", got)
+	}
+}
+
+func TestDecodeUint64Invalid(t *testing.T) {
+	for _, src := range []string{"?", "1?", "?1", "3mJr?", "ab?cd"} {
+		got, err := base62.StdEncoding.DecodeToUint64(src)
+		if err == nil || !strings.Contains(err.Error(), "invalid character") {
+			t.Errorf("DecodeToUint64(%s) = %d, %v, want invalid character error", src, got, err)
+		}
+	}
+}
+
+func TestDecodeUint64TooLong(t *testing.T) {
+	for _, src := range []string{"100000000000", "aaaaaaaaaaaa", strings.Repeat("1", 100)} {
+		got, err := base62.StdEncoding.DecodeToUint64(src)
+		if err == nil || !strings.Contains(err.Error(), "too long") {
+			t.Errorf("DecodeToUint64(%s) = %d, %v, want too long error", src, got, err)
+		}
+	}
+	// leading zero digits do not count
+	for _, src := range []string{"00000000001", "000000000001", strings.Repeat("0", 100) + "1"} {
+		if got, err := base62.StdEncoding.DecodeToUint64(src); err != nil || got != 1 {
+			t.Errorf("DecodeToUint64(%s) = %d, %v, want 1", src, got, err)
+		}
+	}
+}
+
+func TestWithCanonicalEncode(t *testing.T) {
+	canonical := base62.StdEncoding.WithCanonicalEncode()
+	if a, b := canonical.EncodeToString([]byte{0, 0, 5}), canonical.EncodeToString([]byte{5}); a != b || a != "5" {
+		t.Errorf("canonical EncodeToString({0, 0, 5}) = %s and ({5}) = %s, want both 5", a, b)
+	}
+	if got := canonical.EncodeToString([]byte{0, 0}); got != "" {
+		t.Errorf("canonical EncodeToString({0, 0}) = %q, want empty", got)
+	}
+	if got := canonical.EncodeBytes8([8]byte{0, 0, 0, 0, 0, 0, 1, 0}); got != "48" {
+		t.Errorf("canonical EncodeBytes8(256) = %s, want 48", got)
+	}
+	if got := base62.StdEncoding.EncodeToString([]byte{0, 0, 5}); got != "005" {
+		t.Errorf("EncodeToString({0, 0, 5}) = %s, want 005: the standard encoding must be unaffected", got)
+	}
+	if err := canonical.SelfTest(); err != nil {
+		t.Errorf("canonical SelfTest() = %v, want nil", err)
+	}
+	m := map[string][]byte{"": {0}}
+	if got, err := canonical.DecodeToMap(canonical.EncodeMap(m)); err != nil || !bytes.Equal(got[""], m[""]) {
+		t.Errorf("canonical EncodeMap round trip = %v, %v, want %v", got, err, m)
+	}
+}
+
+func TestDecodeToBigInt(t *testing.T) {
+	for x, test := range hexTests {
+		b, _ := hex.DecodeString(test.in)
+		got, err := base62.StdEncoding.DecodeToBigInt(test.out)
+		if err != nil {
+			t.Errorf("DecodeToBigInt test #%d failed: %v", x, err)
+			continue
+		}
+		if want := new(big.Int).SetBytes(b); got.Cmp(want) != 0 {
+			t.Errorf("DecodeToBigInt test #%d failed: got: %v want: %v", x, got, want)
+		}
+	}
+	a, _ := base62.StdEncoding.DecodeToBigInt("10")
+	b, _ := base62.StdEncoding.DecodeToBigInt("10")
+	a.Add(a, a)
+	if b.Int64() != 62 {
+		t.Errorf("DecodeToBigInt results share state: got %v want 62", b)
+	}
+	if got, err := base62.StdEncoding.DecodeToBigInt("qM?n"); err == nil {
+		t.Errorf("DecodeToBigInt(qM?n) = %v, want error", got)
+	}
+}
+
+func TestEncodedLen(t *testing.T) {
+	for n := 0; n <= 1024; n++ {
+		max := base62.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xff}, n))
+		if got := base62.StdEncoding.EncodedLen(n); got != len(max) {
+			t.Fatalf("EncodedLen(%d) = %d, want %d", n, got, len(max))
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		b := make([]byte, rand.Intn(100))
+		rand.Read(b)
+		for j := 0; j < len(b) && j < i%4; j++ {
+			b[j] = 0
+		}
+		s := base62.StdEncoding.EncodeToString(b)
+		if len(s) > base62.StdEncoding.EncodedLen(len(b)) {
+			t.Errorf("EncodeToString(%x) has length %d, above EncodedLen %d", b, len(s), base62.StdEncoding.EncodedLen(len(b)))
+		}
+		if len(b) > base62.StdEncoding.DecodedLen(len(s)) {
+			t.Errorf("DecodeString(%s) has length %d, above DecodedLen %d", s, len(b), base62.StdEncoding.DecodedLen(len(s)))
+		}
+	}
+	if got := base62.StdEncoding.DecodedLen(10); got != 10 {
+		t.Errorf("DecodedLen(10) = %d, want 10", got)
+	}
+}
+
+func TestEncodeAllZeros(t *testing.T) {
+	for n := 0; n <= 64; n++ {
+		zeros := make([]byte, n)
+		s := base62.StdEncoding.EncodeToString(zeros)
+		if want := strings.Repeat("0", n); s != want {
+			t.Errorf("EncodeToString(%d zero bytes) = %q, want %q", n, s, want)
+		}
+		dst := make([]byte, base62.StdEncoding.EncodedLen(n))
+		if k := base62.StdEncoding.Encode(dst, zeros); string(dst[:k]) != s {
+			t.Errorf("Encode(%d zero bytes) = %q, want %q", n, dst[:k], s)
+		}
+		got, err := base62.StdEncoding.DecodeString(s)
+		if err != nil || !bytes.Equal(got, zeros) {
+			t.Errorf("DecodeString(%q) = %x, %v, want %d zero bytes", s, got, err, n)
+		}
+	}
+}
+
+func TestEncodedLenStats(t *testing.T) {
+	canonical := base62.StdEncoding.WithCanonicalEncode()
+	for _, n := range []int{0, 1, 2, 3, 8, 16, 20, 32, 64, 100, 255, 256} {
+		for _, enc := range []*base62.Encoding{base62.StdEncoding, canonical} {
+			min, max := enc.EncodedLenStats(n)
+			if got := len(enc.EncodeToString(make([]byte, n))); got != min {
+				t.Errorf("EncodedLenStats(%d) min = %d, want %d", n, min, got)
+			}
+			if got := len(enc.EncodeToString(bytes.Repeat([]byte{0xff}, n))); got != max {
+				t.Errorf("EncodedLenStats(%d) max = %d, want %d", n, max, got)
+			}
+			for i := 0; i < 200; i++ {
+				b := make([]byte, n)
+				rand.Read(b)
+				for j := 0; j < n && j < i%4; j++ {
+					b[j] = 0
+				}
+				if l := len(enc.EncodeToString(b)); l < min || l > max {
+					t.Errorf("EncodeToString(%x) has length %d, outside EncodedLenStats(%d) = %d, %d", b, l, n, min, max)
+				}
+			}
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range stringTests {
+		for _, enc := range []*base62.Encoding{base62.StdEncoding, base62.StdEncoding.WithCanonicalEncode()} {
+			dst := bytes.Repeat([]byte{'#'}, enc.EncodedLen(len(tt.in)))
+			n := enc.Encode(dst, []byte(tt.in))
+			if got, want := string(dst[:n]), enc.EncodeToString([]byte(tt.in)); got != want {
+				t.Errorf("Encode(%q) = %q, want %q", tt.in, got, want)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Encode into a too small buffer did not panic")
+		}
+	}()
+	base62.StdEncoding.Encode(make([]byte, 1), []byte("hello"))
+}
+
+func TestDecode(t *testing.T) {
+	for _, tt := range stringTests {
+		want, err := base62.StdEncoding.DecodeString(tt.out)
+		if err != nil {
+			t.Fatalf("DecodeString(%q) error = %v", tt.out, err)
+		}
+		dst := bytes.Repeat([]byte{0xaa}, len(tt.out)+8)
+		n, err := base62.StdEncoding.Decode(dst, []byte(tt.out))
+		if err != nil || !bytes.Equal(dst[:n], want) {
+			t.Errorf("Decode(%q) = %x, %v, want %x", tt.out, dst[:n], err, want)
+		}
+		for _, c := range dst[n:] {
+			if c != 0xaa {
+				t.Errorf("Decode(%q) wrote past its result: %x", tt.out, dst[n:])
+				break
+			}
+		}
+	}
+
+	// leading zero digits land in a dirty buffer as zero bytes
+	dst := bytes.Repeat([]byte{0xaa}, 8)
+	n, err := base62.StdEncoding.Decode(dst, []byte("001"))
+	if err != nil || !bytes.Equal(dst[:n], []byte{0, 0, 1}) {
+		t.Errorf("Decode(%q) = %x, %v, want 000001", "001", dst[:n], err)
+	}
+
+	if _, err := base62.StdEncoding.Decode(dst, []byte("a?b")); err == nil {
+		t.Errorf("Decode(%q) should fail", "a?b")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Decode into a too small buffer did not panic")
+		}
+	}()
+	base62.StdEncoding.Decode(make([]byte, 1), []byte("7tQLFHz3"))
+}
+
+var newEncodingTests = []struct {
+	alphabet string
+	valid    bool
+}{
+	{"0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ", true},
+	{"zyxwvutsrqponmlkjihgfedcbaZYXWVUTSRQPONMLKJIHGFEDCBA9876543210", true},
+	{"0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXY", false},
+	{"0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0", false},
+	{"0123456789aacdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ", false},
+	{"0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXY\x00", false},
+	{"", false},
+}
+
+func TestNewEncoding(t *testing.T) {
+	for _, tt := range newEncodingTests {
+		enc, err := base62.NewEncoding(tt.alphabet)
+		if (err == nil) != tt.valid {
+			t.Errorf("NewEncoding(%q) error = %v, want valid %v", tt.alphabet, err, tt.valid)
+			continue
+		}
+		if tt.valid && string(enc.AlphabetBytes()) != tt.alphabet {
+			t.Errorf("NewEncoding(%q).AlphabetBytes() = %q", tt.alphabet, enc.AlphabetBytes())
+		}
+		func() {
+			defer func() {
+				if r := recover(); (r == nil) != tt.valid {
+					t.Errorf("New(%q) panic = %v, want panic %v", tt.alphabet, r, !tt.valid)
+				}
+			}()
+			base62.New([]byte(tt.alphabet))
+		}()
+	}
+}
+
+func TestEncodeUint32(t *testing.T) {
+	if s := base62.StdEncoding.EncodeUint32(0); s != "0" {
+		t.Errorf("EncodeUint32(%d) = %s, want %s", 0, s, "0")
+	}
+	for i := 0; i < 100; i++ {
+		n := rand.Uint32() % uint32(math.Pow10(i/10))
+		if actual, expected := base62.StdEncoding.EncodeUint32(n), base62.StdEncoding.EncodeUint64(uint64(n)); actual != expected {
+			t.Errorf("EncodeUint32(%d) = %s, want %s", n, actual, expected)
+		}
+	}
+	if s := base62.StdEncoding.EncodeUint32(math.MaxUint32); s != "4GFfc3" {
+		t.Errorf("EncodeUint32(%d) = %s, want %s", uint32(math.MaxUint32), s, "4GFfc3")
+	}
+}
+
+func TestDecodeUint32(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n := rand.Uint32() % uint32(math.Pow10(i/10))
+		src := base62.StdEncoding.EncodeUint32(n)
+		got, err := base62.StdEncoding.DecodeToUint32(src)
+		if err != nil {
+			t.Fatalf("Error occurred while decoding %s (%s).", src, err)
+		}
+		if got != n {
+			t.Errorf("DecodeUint32(%s) = %d, want %d", src, got, n)
+		}
+	}
+}
+
+func TestDecodeUint32Overflow(t *testing.T) {
+	src := base62.StdEncoding.EncodeUint32(math.MaxUint32)
+	got, err := base62.StdEncoding.DecodeToUint32(src)
+	if err != nil || got != math.MaxUint32 {
+		t.Fatalf("DecodeUint32(%s) = %d, %v, want %d", src, got, err, uint32(math.MaxUint32))
+	}
+	for _, src := range []string{"4GFfc4", "ZZZZZZ", "1000000"} {
+		if got, err := base62.StdEncoding.DecodeToUint32(src); err == nil {
+			t.Errorf("Overflow error should occur while decoding %s but got %d.", src, got)
+		}
+	}
+}
+
+func TestUint32Options(t *testing.T) {
+	ext, err := base62.StdEncoding.Extend([]byte("-_.~"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sep := base62.StdEncoding.WithDigitSeparators()
+	for _, enc := range []*base62.Encoding{ext, sep} {
+		for _, n := range []uint32{0, 61, 65, 1 << 20, math.MaxUint32} {
+			s := enc.EncodeUint32(n)
+			if want := enc.EncodeUint64(uint64(n)); s != want {
+				t.Errorf("EncodeUint32(%d) = %s, want %s", n, s, want)
+			}
+			if got, err := enc.DecodeToUint32(s); err != nil || got != n {
+				t.Errorf("DecodeToUint32(%s) = %d, %v, want %d", s, got, err, n)
+			}
+		}
+		if got, err := enc.DecodeToUint32(enc.EncodeUint64(math.MaxUint32 + 1)); err == nil {
+			t.Errorf("DecodeToUint32(MaxUint32+1) = %d, want overflow error", got)
+		}
+	}
+	if got, err := sep.DecodeToUint32("4G_Ffc3"); err != nil || got != math.MaxUint32 {
+		t.Errorf("DecodeToUint32(4G_Ffc3) = %d, %v, want %d", got, err, uint32(math.MaxUint32))
+	}
+}
+
+func TestEncodeBigInt(t *testing.T) {
+	values := []string{"0", "1", "61", "62", "256", "18446744073709551615", "18446744073709551616", "340282366920938463463374607431768211455"}
+	for _, v := range values {
+		x, _ := new(big.Int).SetString(v, 10)
+		s := base62.StdEncoding.EncodeBigInt(x)
+		if x.IsUint64() {
+			if want := base62.StdEncoding.EncodeUint64(x.Uint64()); s != want {
+				t.Errorf("EncodeBigInt(%v) = %s, want %s", x, s, want)
+			}
+		}
+		got, err := base62.StdEncoding.DecodeToBigInt(s)
+		if err != nil || got.Cmp(x) != 0 {
+			t.Errorf("DecodeToBigInt(%s) = %v, %v, want %v", s, got, err, x)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EncodeBigInt(-1) did not panic")
+		}
+	}()
+	base62.StdEncoding.EncodeBigInt(big.NewInt(-1))
+}
+
+func TestWithDigitSeparators(t *testing.T) {
+	enc := base62.StdEncoding.WithDigitSeparators()
+	for _, tt := range []struct {
+		in   string
+		want uint64
+	}{
+		{"1_2_3", 62*62 + 2*62 + 3},
+		{"10_00", 62 * 62 * 62},
+		{"lYG_hA16_ahyf", math.MaxUint64},
+		{"0", 0},
+	} {
+		if got, err := enc.DecodeToUint64(tt.in); err != nil || got != tt.want {
+			t.Errorf("DecodeToUint64(%s) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, src := range []string{"_123", "123_", "1__23", "_", "1_2_3_4_5_6_7_8_9_0_1_2"} {
+		if got, err := enc.DecodeToUint64(src); err == nil {
+			t.Errorf("DecodeToUint64(%s) = %d, want error", src, got)
+		}
+	}
+	if got, err := base62.StdEncoding.DecodeToUint64("1_2_3"); err == nil {
+		t.Errorf("DecodeToUint64(1_2_3) without separators = %d, want error", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithDigitSeparators on an alphabet with '_' did not panic")
+		}
+	}()
+	noAmbiguous.WithDigitSeparators()
+}
+
+func TestEncodeMatchesBigInt(t *testing.T) {
+	for n := 0; n <= 200; n++ {
+		for _, b := range [][]byte{bytes.Repeat([]byte{0xff}, n), bytes.Repeat([]byte{0x01}, n), make([]byte, n)} {
+			if got, want := base62.StdEncoding.EncodeToString(b), bigIntEncode(b); got != want {
+				t.Fatalf("EncodeToString(%x) = %s, want %s", b, got, want)
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		b := make([]byte, rand.Intn(300))
+		rand.Read(b)
+		for j := 0; j < len(b) && j < i%3; j++ {
+			b[j] = 0
+		}
+		if got, want := base62.StdEncoding.EncodeToString(b), bigIntEncode(b); got != want {
+			t.Fatalf("EncodeToString(%x) = %s, want %s", b, got, want)
+		}
+	}
+	// powers of the radix end in zero digit groups
+	one := big.NewInt(1)
+	for k := int64(1); k <= 100; k++ {
+		x := new(big.Int).Exp(big.NewInt(62), big.NewInt(k), nil)
+		for _, b := range [][]byte{x.Bytes(), new(big.Int).Sub(x, one).Bytes()} {
+			if got, want := base62.StdEncoding.EncodeToString(b), bigIntEncode(b); got != want {
+				t.Errorf("EncodeToString(%x) = %s, want %s", b, got, want)
+			}
+		}
+	}
+}
+
+func TestDecodeMatchesBigInt(t *testing.T) {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	for i := 0; i < 2000; i++ {
+		s := make([]byte, rand.Intn(400))
+		for j := range s {
+			s[j] = alphabet[rand.Intn(len(alphabet))]
+		}
+		for j := 0; j < len(s) && j < i%3; j++ {
+			s[j] = '0'
+		}
+		x, err := base62.StdEncoding.DecodeToBigInt(string(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		zeros := len(s) - len(strings.TrimLeft(string(s), "0"))
+		want := append(make([]byte, zeros), x.Bytes()...)
+		if got, err := base62.StdEncoding.DecodeString(string(s)); err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("DecodeString(%s) = %x, %v, want %x", s, got, err, want)
+		}
+	}
+}
+
+func TestAppendEncodeDecode(t *testing.T) {
+	var enc, dec []byte
+	var wantEnc string
+	var wantDec []byte
+	for _, tt := range stringTests {
+		enc = base62.StdEncoding.AppendEncode(enc, []byte(tt.in))
+		enc = append(enc, ' ')
+		wantEnc += base62.StdEncoding.EncodeToString([]byte(tt.in)) + " "
+
+		var err error
+		if dec, err = base62.StdEncoding.AppendDecode(dec, []byte(tt.out)); err != nil {
+			t.Fatalf("AppendDecode(%q) error = %v", tt.out, err)
+		}
+		want, _ := base62.StdEncoding.DecodeString(tt.out)
+		wantDec = append(wantDec, want...)
+	}
+	if string(enc) != wantEnc {
+		t.Errorf("AppendEncode = %q, want %q", enc, wantEnc)
+	}
+	if !bytes.Equal(dec, wantDec) {
+		t.Errorf("AppendDecode = %q, want %q", dec, wantDec)
+	}
+
+	prefix := []byte("prefix")
+	got, err := base62.StdEncoding.AppendDecode(prefix, []byte("a?b"))
+	if err == nil || string(got) != "prefix" {
+		t.Errorf("AppendDecode(a?b) = %q, %v, want %q and error", got, err, "prefix")
+	}
+}
+
+func TestGMPEncodingSorts(t *testing.T) {
+	values := []int64{math.MinInt64, -1, 0, 9, 10, 35, 36, 61, 62, 1 << 40, math.MaxInt64}
+	stdSorted := true
+	for i := 1; i < len(values); i++ {
+		a, b := base62.GMPEncoding.EncodeInt64Ordered(values[i-1]), base62.GMPEncoding.EncodeInt64Ordered(values[i])
+		if a >= b {
+			t.Errorf("GMPEncoding: %s (%d) >= %s (%d)", a, values[i-1], b, values[i])
+		}
+		if base62.StdEncoding.EncodeInt64Ordered(values[i-1]) >= base62.StdEncoding.EncodeInt64Ordered(values[i]) {
+			stdSorted = false
+		}
+	}
+	if stdSorted {
+		t.Errorf("StdEncoding sorts like GMPEncoding, want 35 and 36 out of order")
+	}
+}
+
+// TestConcurrentUse shares encodings between goroutines; run it with -race.
+func TestConcurrentUse(t *testing.T) {
+	encodings := []*base62.Encoding{
+		base62.StdEncoding,
+		base62.GMPEncoding.WithCanonicalEncode(),
+		base62.StdEncoding.WithCaseHint().WithDigitSeparators(),
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < 200; i++ {
+				enc := encodings[rnd.Intn(len(encodings))]
+				b := make([]byte, rnd.Intn(64))
+				rnd.Read(b)
+				s := enc.EncodeToString(b)
+				got, err := enc.DecodeString(s)
+				if err != nil || !bytes.Equal(got, bytes.TrimLeft(b, "\x00")) && !bytes.Equal(got, b) {
+					errs <- fmt.Errorf("DecodeString(EncodeToString(%x)) = %x, %v", b, got, err)
+					return
+				}
+				n := rnd.Uint64()
+				if got, err := enc.DecodeToUint64(enc.EncodeUint64(n)); err != nil || got != n {
+					errs <- fmt.Errorf("DecodeToUint64(EncodeUint64(%d)) = %d, %v", n, got, err)
+					return
+				}
+				dst := make([]byte, enc.EncodedLen(len(b)))
+				enc.Encode(dst, b)
+				enc.Fingerprint(b, 8)
+				enc.CorrectOCR("O1l")
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDecodeInPlace(t *testing.T) {
+	for _, tt := range stringTests {
+		buf := []byte(tt.out)
+		n, err := base62.StdEncoding.DecodeInPlace(buf)
+		if err != nil || string(buf[:n]) != tt.in {
+			t.Errorf("DecodeInPlace(%q) = %q, %v, want %q", tt.out, buf[:n], err, tt.in)
+		}
+	}
+	for x, test := range hexTests {
+		buf := []byte(test.out)
+		n, err := base62.StdEncoding.DecodeInPlace(buf)
+		if got := hex.EncodeToString(buf[:n]); err != nil || got != test.in {
+			t.Errorf("DecodeInPlace test #%d = %s, %v, want %s", x, got, err, test.in)
+		}
+	}
+	buf := []byte("abc?")
+	if _, err := base62.StdEncoding.DecodeInPlace(buf); err == nil || string(buf) != "abc?" {
+		t.Errorf("DecodeInPlace(abc?) = %q, %v, want unchanged buffer and error", buf, err)
+	}
+}
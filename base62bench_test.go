@@ -7,6 +7,7 @@ package base62_test
 import (
 	"bytes"
 	"github.com/schwid/base62"
+	"math/big"
 	"testing"
 )
 
@@ -44,3 +45,138 @@ func BenchmarkBase62Decode_100K(b *testing.B) {
 		base62.StdEncoding.DecodeString(encoded100k)
 	}
 }
+
+func BenchmarkBase62DecodeToBigInt_5K(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded5k)))
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.DecodeToBigInt(encoded5k)
+	}
+}
+
+func BenchmarkBase62DecodeToBigInt_100K(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded100k)))
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.DecodeToBigInt(encoded100k)
+	}
+}
+
+// DecodeSetBytes is the byte slice round trip that DecodeToBigInt saves.
+
+func BenchmarkBase62DecodeSetBytes_5K(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded5k)))
+	for i := 0; i < b.N; i++ {
+		v, _ := base62.StdEncoding.DecodeString(encoded5k)
+		new(big.Int).SetBytes(v)
+	}
+}
+
+func BenchmarkBase62DecodeSetBytes_100K(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(encoded100k)))
+	for i := 0; i < b.N; i++ {
+		v, _ := base62.StdEncoding.DecodeString(encoded100k)
+		new(big.Int).SetBytes(v)
+	}
+}
+
+func BenchmarkBase62MatchesCharset_100K(b *testing.B) {
+	b.SetBytes(int64(len(encoded100k)))
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.MatchesCharset(encoded100k)
+	}
+}
+
+// bigIntEncode is the big.Int implementation EncodeToString used before the
+// limb arithmetic, kept as a reference for comparison.
+func bigIntEncode(b []byte) string {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	radix10 := big.NewInt(62 * 62 * 62 * 62 * 62 * 62 * 62 * 62 * 62 * 62)
+	x := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+	var answer []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, radix10, mod)
+		m := mod.Int64()
+		for i := 0; i < 10 && (x.Sign() > 0 || m > 0); i++ {
+			answer = append(answer, alphabet[m%62])
+			m /= 62
+		}
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		answer = append(answer, alphabet[0])
+	}
+	for i, j := 0, len(answer)-1; i < j; i, j = i+1, j-1 {
+		answer[i], answer[j] = answer[j], answer[i]
+	}
+	return string(answer)
+}
+
+func BenchmarkBigIntEncode_5K(b *testing.B) {
+	b.SetBytes(int64(len(raw5k)))
+	for i := 0; i < b.N; i++ {
+		bigIntEncode(raw5k)
+	}
+}
+
+func BenchmarkBigIntEncode_100K(b *testing.B) {
+	b.SetBytes(int64(len(raw100k)))
+	for i := 0; i < b.N; i++ {
+		bigIntEncode(raw100k)
+	}
+}
+
+var raw32 = bytes.Repeat([]byte{0xa5}, 32)
+
+func BenchmarkBase62Encode_32(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.EncodeToString(raw32)
+	}
+}
+
+func BenchmarkCoderEncode_32(b *testing.B) {
+	c := base62.StdEncoding.NewCoder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.EncodeToString(raw32)
+	}
+}
+
+func BenchmarkBase62Decode_32(b *testing.B) {
+	s := base62.StdEncoding.EncodeToString(raw32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.DecodeString(s)
+	}
+}
+
+func BenchmarkCoderDecode_32(b *testing.B) {
+	c := base62.StdEncoding.NewCoder()
+	s := base62.StdEncoding.EncodeToString(raw32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.DecodeString(s)
+	}
+}
+
+var raw16 = [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+func BenchmarkBase62Encode_16(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.EncodeToString(raw16[:])
+	}
+}
+
+func BenchmarkBase62Encode_UUID(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.StdEncoding.EncodeUUID(raw16)
+	}
+}
@@ -0,0 +1,186 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// The block format splits the input into blocks of BlockBytes bytes and
+// encodes every block into exactly BlockChars characters, padding with the
+// zero digit. Only the final block may be shorter; it is encoded in the
+// smallest width able to hold it, and that width is unique for every short
+// block length, so the decoder recovers the exact byte count. Because every
+// block has a fixed position, any block-aligned section of an encoded
+// stream can be decoded on its own.
+const (
+	BlockBytes = 8
+	BlockChars = 11
+)
+
+// blockWidths maps the byte length of a block to its encoded width.
+var blockWidths = [BlockBytes + 1]int{0, 2, 3, 5, 6, 7, 9, 10, 11}
+
+// blockEncodedLen returns the length of the block format of n bytes.
+func blockEncodedLen(n int) int {
+	return n/BlockBytes*BlockChars + blockWidths[n%BlockBytes]
+}
+
+// EncodeBlocks encodes src in the block format.
+func (e *Encoding) EncodeBlocks(src []byte) string {
+	dst := make([]byte, blockEncodedLen(len(src)))
+	for i := 0; len(src) > 0; i += BlockChars {
+		k := len(src)
+		if k > BlockBytes {
+			k = BlockBytes
+		}
+		e.encodeBlock(dst[i:i+blockWidths[k]], src[:k])
+		src = src[k:]
+	}
+	return string(dst)
+}
+
+// DecodeBlocks decodes a string produced by EncodeBlocks.
+func (e *Encoding) DecodeBlocks(s string) ([]byte, error) {
+	dst := make([]byte, 0, len(s)/BlockChars*BlockBytes+BlockBytes)
+	var block [BlockBytes]byte
+	for len(s) > 0 {
+		k := len(s)
+		if k > BlockChars {
+			k = BlockChars
+		}
+		n, err := e.decodeBlock(block[:], s[:k])
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, block[:n]...)
+		s = s[k:]
+	}
+	return dst, nil
+}
+
+// DecodeBlocksPartial decodes the block format incrementally, for input
+// that arrives in pieces: it decodes the whole blocks at the start of src
+// into dst and returns the number of bytes written and of characters
+// consumed, so that the caller keeps src[nsrc:] and feeds it again, with
+// more input appended, in the next call. ASCII spaces, tabs and line breaks
+// are skipped and count as consumed.
+//
+// Blocks are BlockChars characters long, whitespace aside, so nsrc always
+// ends on a block boundary. A trailing partial block is left unconsumed
+// unless atEOF is set, which marks src as the end of the stream: the
+// partial block is then decoded as the final one, and an error is
+// returned if its length is not that of a final block. Decoding stops with
+// io.ErrShortBuffer before a block that does not fit in dst, and with the
+// decoding error at an invalid block; in both cases ndst and nsrc cover the
+// blocks decoded before, which can be kept.
+func (e *Encoding) DecodeBlocksPartial(dst, src []byte, atEOF bool) (ndst, nsrc int, err error) {
+	var block [BlockChars]byte
+	for {
+		k, i := 0, nsrc
+		for ; i < len(src) && k < BlockChars; i++ {
+			if !isSpace(src[i]) {
+				block[k] = src[i]
+				k++
+			}
+		}
+		if k == 0 {
+			return ndst, i, nil
+		}
+		if k < BlockChars && !atEOF {
+			return ndst, nsrc, nil
+		}
+		need := 0
+		for n, w := range blockWidths {
+			if w == k {
+				need = n
+			}
+		}
+		if len(dst)-ndst < need {
+			return ndst, nsrc, io.ErrShortBuffer
+		}
+		n, err := e.decodeBlock(dst[ndst:], string(block[:k]))
+		if err != nil {
+			return ndst, nsrc, err
+		}
+		ndst += n
+		nsrc = i
+	}
+}
+
+// DecodeSection decodes length characters of a block-encoded stream
+// starting at offset off. The offset must fall on a block boundary and the
+// section must either consist of whole blocks or end with the final block
+// of the stream.
+func (e *Encoding) DecodeSection(r io.ReaderAt, off, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, fmt.Errorf("invalid base62 section at offset %d of length %d", off, length)
+	}
+	if off%BlockChars != 0 {
+		return nil, fmt.Errorf("base62 section offset %d is not aligned to a %d character block", off, BlockChars)
+	}
+	buf := make([]byte, length)
+	if n, err := r.ReadAt(buf, off); n < len(buf) {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if length%BlockChars != 0 {
+		var next [1]byte
+		if n, _ := r.ReadAt(next[:], off+length); n != 0 {
+			return nil, fmt.Errorf("base62 section of length %d ends inside a %d character block", length, BlockChars)
+		}
+	}
+	return e.DecodeBlocks(string(buf))
+}
+
+// encodeBlock encodes up to BlockBytes bytes of src into dst, which must be
+// exactly blockWidths[len(src)] long.
+func (e *Encoding) encodeBlock(dst []byte, src []byte) {
+	var buf [BlockBytes]byte
+	copy(buf[BlockBytes-len(src):], src)
+	n := binary.BigEndian.Uint64(buf[:])
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = e.alphabet[n%radix]
+		n /= radix
+	}
+}
+
+// decodeBlock decodes a single encoded block into dst and returns the
+// number of bytes written.
+func (e *Encoding) decodeBlock(dst []byte, src string) (int, error) {
+	k := -1
+	for i, w := range blockWidths {
+		if w == len(src) && i > 0 {
+			k = i
+		}
+	}
+	if k < 0 {
+		return 0, fmt.Errorf("invalid block length %d in decoding a base62 block %q", len(src), src)
+	}
+	var n uint64
+	for i := 0; i < len(src); i++ {
+		c := e.decodeMap[src[i]]
+		if c == 255 {
+			return 0, fmt.Errorf("invalid character %q in decoding a base62 block %q", src[i], src)
+		}
+		hi, lo := bits.Mul64(n, radix)
+		lo, carry := bits.Add64(lo, uint64(c), 0)
+		if hi != 0 || carry != 0 {
+			return 0, fmt.Errorf("overflow in decoding a base62 block %q", src)
+		}
+		n = lo
+	}
+	if k < BlockBytes && n>>(8*k) != 0 {
+		return 0, fmt.Errorf("overflow in decoding a base62 block %q", src)
+	}
+	var buf [BlockBytes]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	return copy(dst, buf[BlockBytes-k:]), nil
+}
@@ -0,0 +1,139 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestBlocks(t *testing.T) {
+	for n := 0; n <= 4*base62.BlockBytes; n++ {
+		in := make([]byte, n)
+		rand.Read(in)
+		if n%3 == 0 {
+			in = bytes.Repeat([]byte{0xff}, n)
+		}
+		s := base62.StdEncoding.EncodeBlocks(in)
+		if n%base62.BlockBytes == 0 && len(s) != n/base62.BlockBytes*base62.BlockChars {
+			t.Errorf("EncodeBlocks of %d bytes has length %d", n, len(s))
+		}
+		got, err := base62.StdEncoding.DecodeBlocks(s)
+		if err != nil {
+			t.Errorf("DecodeBlocks(%s) failed: %v", s, err)
+			continue
+		}
+		if !bytes.Equal(got, in) {
+			t.Errorf("DecodeBlocks(%s) = %x, want %x", s, got, in)
+		}
+	}
+}
+
+func TestBlocksInvalid(t *testing.T) {
+	for _, s := range []string{
+		"0",                  // no block is one character long
+		"0000",               // nor four
+		"00000000000" + "0",  // full block followed by an impossible one
+		"ZZZZZZZZZZZ",        // exceeds 64 bits
+		"ZZ",                 // exceeds 8 bits
+		"0000000000?",        // invalid character
+		"00000000000" + "?0", // invalid character in the final block
+	} {
+		if got, err := base62.StdEncoding.DecodeBlocks(s); err == nil {
+			t.Errorf("DecodeBlocks(%s) = %x, want error", s, got)
+		}
+	}
+}
+
+func TestDecodeSection(t *testing.T) {
+	in := make([]byte, 10*base62.BlockBytes+5)
+	rand.Read(in)
+	file := strings.NewReader(base62.StdEncoding.EncodeBlocks(in))
+
+	got, err := base62.StdEncoding.DecodeSection(file, 2*base62.BlockChars, 3*base62.BlockChars)
+	if err != nil {
+		t.Fatalf("DecodeSection of the middle failed: %v", err)
+	}
+	if want := in[2*base62.BlockBytes : 5*base62.BlockBytes]; !bytes.Equal(got, want) {
+		t.Errorf("DecodeSection of the middle = %x, want %x", got, want)
+	}
+
+	tail := int64(file.Len()) - 8*base62.BlockChars
+	got, err = base62.StdEncoding.DecodeSection(file, 8*base62.BlockChars, tail)
+	if err != nil {
+		t.Fatalf("DecodeSection of the tail failed: %v", err)
+	}
+	if want := in[8*base62.BlockBytes:]; !bytes.Equal(got, want) {
+		t.Errorf("DecodeSection of the tail = %x, want %x", got, want)
+	}
+
+	if _, err := base62.StdEncoding.DecodeSection(file, 5, base62.BlockChars); err == nil {
+		t.Errorf("DecodeSection at a misaligned offset should fail")
+	}
+	if _, err := base62.StdEncoding.DecodeSection(file, 0, base62.BlockChars+3); err == nil {
+		t.Errorf("DecodeSection ending inside a block should fail")
+	}
+	if _, err := base62.StdEncoding.DecodeSection(file, 0, int64(file.Len())+base62.BlockChars); err == nil {
+		t.Errorf("DecodeSection past the end should fail")
+	}
+}
+
+func TestDecodeBlocksPartial(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 8, 9, 16, 17, 100} {
+		in := make([]byte, size)
+		rand.Read(in)
+		enc := base62.StdEncoding.EncodeBlocks(in)
+		// one character at a time, as from a source that may drop anywhere
+		var pending, out []byte
+		dst := make([]byte, 64)
+		for i := 0; i <= len(enc); i++ {
+			atEOF := i == len(enc)
+			if !atEOF {
+				pending = append(pending, enc[i])
+			}
+			ndst, nsrc, err := base62.StdEncoding.DecodeBlocksPartial(dst, pending, atEOF)
+			if err != nil {
+				t.Fatalf("DecodeBlocksPartial(%q, %v) error = %v", pending, atEOF, err)
+			}
+			if !atEOF && nsrc%base62.BlockChars != 0 {
+				t.Fatalf("DecodeBlocksPartial(%q) consumed %d characters, not block aligned", pending, nsrc)
+			}
+			out = append(out, dst[:ndst]...)
+			pending = append(pending[:0], pending[nsrc:]...)
+		}
+		if len(pending) != 0 || !bytes.Equal(out, in) {
+			t.Errorf("DecodeBlocksPartial of %d bytes = %x with %q left, want %x", size, out, pending, in)
+		}
+	}
+
+	enc := base62.StdEncoding.EncodeBlocks([]byte("resumable stream"))
+	wrapped := enc[:5] + "\n" + enc[5:14] + " \t" + enc[14:]
+	dst := make([]byte, 64)
+	ndst, nsrc, err := base62.StdEncoding.DecodeBlocksPartial(dst, []byte(wrapped), false)
+	if err != nil || string(dst[:ndst]) != "resumable stream" || nsrc != len(wrapped) {
+		t.Errorf("DecodeBlocksPartial(%q) = %q, %d, %v", wrapped, dst[:ndst], nsrc, err)
+	}
+	// a partial block is kept for the next call until EOF
+	ndst, nsrc, err = base62.StdEncoding.DecodeBlocksPartial(dst, []byte(enc[:15]), false)
+	if err != nil || ndst != 8 || nsrc != 11 {
+		t.Errorf("DecodeBlocksPartial(%q, false) = %d, %d, %v, want 8, 11", enc[:15], ndst, nsrc, err)
+	}
+	if _, _, err = base62.StdEncoding.DecodeBlocksPartial(dst, []byte(enc[:15]), true); err == nil {
+		t.Errorf("DecodeBlocksPartial(%q, true) expected error for a 4 character final block", enc[:15])
+	}
+	ndst, nsrc, err = base62.StdEncoding.DecodeBlocksPartial(dst[:12], []byte(enc), true)
+	if err != io.ErrShortBuffer || ndst != 8 || nsrc != 11 {
+		t.Errorf("DecodeBlocksPartial(short dst) = %d, %d, %v, want 8, 11, io.ErrShortBuffer", ndst, nsrc, err)
+	}
+	bad := enc[:11] + "?" + enc[12:]
+	ndst, nsrc, err = base62.StdEncoding.DecodeBlocksPartial(dst, []byte(bad), true)
+	if err == nil || ndst != 8 || nsrc != 11 {
+		t.Errorf("DecodeBlocksPartial(%q) = %d, %d, %v, want 8, 11 and an error", bad, ndst, nsrc, err)
+	}
+}
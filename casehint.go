@@ -0,0 +1,74 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// CaseHintError is returned by the decode methods of an encoding created
+// with WithCaseHint when the input fails to decode but would have decoded
+// with the case of its letters swapped, the usual result of caps lock.
+// The swapped input is only suggested, never decoded on the caller's behalf.
+type CaseHintError struct {
+	Input      string
+	Suggestion string
+	Err        error
+}
+
+func (e *CaseHintError) Error() string {
+	return fmt.Sprintf("%v; the input with swapped case %q would decode", e.Err, e.Suggestion)
+}
+
+func (e *CaseHintError) Unwrap() error {
+	return e.Err
+}
+
+// WithCaseHint returns a copy of the encoding whose Decode, DecodeString,
+// DecodeToUint64, DecodeCorrectable, DecodeWithDomain, DecodeCheck and
+// DecodeToUint64Check retry a failed decode with the case of every letter
+// swapped. When the retry succeeds the
+// original error is wrapped in a *CaseHintError carrying the swapped input;
+// the result of a successful decode is never changed. A swapped input that
+// decodes without error, such as one miscorrected by DecodeCorrectable,
+// gives no hint.
+func (e *Encoding) WithCaseHint() *Encoding {
+	c := *e
+	c.caseHint = true
+	return &c
+}
+
+// caseHintError returns err, wrapped in a *CaseHintError when the case hint
+// is enabled and decode succeeds on the case swapped s.
+func (e *Encoding) caseHintError(s string, err error, decode func(e *Encoding, s string) error) error {
+	if !e.caseHint || err == nil {
+		return err
+	}
+	if _, ok := err.(*CaseHintError); ok {
+		// already hinted by a nested decode
+		return err
+	}
+	swapped := swapCase(s)
+	if swapped == s {
+		return err
+	}
+	plain := *e
+	plain.caseHint = false
+	if decode(&plain, swapped) != nil {
+		return err
+	}
+	return &CaseHintError{Input: s, Suggestion: swapped, Err: err}
+}
+
+func swapCase(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case 'a' <= c && c <= 'z':
+			b[i] = c - 'a' + 'A'
+		case 'A' <= c && c <= 'Z':
+			b[i] = c - 'A' + 'a'
+		}
+	}
+	return string(b)
+}
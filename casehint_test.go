@@ -0,0 +1,94 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"errors"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+// lowerEncoding has no upper case letters, so upper case input is invalid.
+var lowerEncoding = base62.New([]byte("0123456789abcdefghijklmnopqrstuvwxyz!#$%&()*+,-./:;<=>?@[]^_{}"))
+
+func TestCaseHint(t *testing.T) {
+	hinted := lowerEncoding.WithCaseHint()
+
+	_, err := hinted.DecodeString("HELLO")
+	var hint *base62.CaseHintError
+	if !errors.As(err, &hint) {
+		t.Fatalf("DecodeString(%q) error = %v, want *CaseHintError", "HELLO", err)
+	}
+	if hint.Input != "HELLO" || hint.Suggestion != "hello" {
+		t.Errorf("CaseHintError = %+v, want suggestion %q for %q", hint, "hello", "HELLO")
+	}
+	if errors.Unwrap(err) == nil {
+		t.Errorf("CaseHintError does not wrap the decode error")
+	}
+
+	// without the option the error is plain
+	if _, err := lowerEncoding.DecodeString("HELLO"); err == nil || errors.As(err, &hint) {
+		t.Errorf("DecodeString(%q) error = %v, want plain error", "HELLO", err)
+	}
+	// no hint when the swapped input does not decode either
+	if _, err := hinted.DecodeString("HE~LO"); err == nil || errors.As(err, &hint) {
+		t.Errorf("DecodeString(%q) error = %v, want plain error", "HE~LO", err)
+	}
+	// valid input is unaffected
+	if _, err := hinted.DecodeString("hello"); err != nil {
+		t.Errorf("DecodeString(%q) error = %v", "hello", err)
+	}
+}
+
+func TestCaseHintStd(t *testing.T) {
+	hinted := base62.StdEncoding.WithCaseHint()
+	var hint *base62.CaseHintError
+
+	// max uint64 is "lYGhA16ahyf", swapped it overflows
+	if _, err := hinted.DecodeToUint64("LygHa16AHYF"); !errors.As(err, &hint) || hint.Suggestion != "lYGhA16ahyf" {
+		t.Errorf("DecodeToUint64(%q) error = %v, want *CaseHintError", "LygHa16AHYF", err)
+	}
+
+	// a swap is many errors at once, here beyond correction; other inputs
+	// may be miscorrected instead and then give no hint
+	s := base62.StdEncoding.EncodeCorrectable([]byte("order 1234"))
+	if _, _, err := hinted.DecodeCorrectable(swap(s)); !errors.As(err, &hint) || hint.Suggestion != s {
+		t.Errorf("DecodeCorrectable(%q) error = %v, want *CaseHintError", swap(s), err)
+	}
+
+	s = base62.StdEncoding.EncodeWithDomain("invite", []byte("user id 42"))
+	if _, err := hinted.DecodeWithDomain("invite", swap(s)); !errors.As(err, &hint) || hint.Suggestion != s {
+		t.Errorf("DecodeWithDomain(%q) error = %v, want *CaseHintError", swap(s), err)
+	}
+	if _, err := hinted.DecodeWithDomain("other", swap(s)); err == nil || errors.As(err, &hint) {
+		t.Errorf("DecodeWithDomain(%q) with wrong domain error = %v, want plain error", swap(s), err)
+	}
+
+	s = base62.StdEncoding.EncodeCheck([]byte("payment 77"))
+	if _, err := hinted.DecodeCheck(swap(s)); !errors.As(err, &hint) || hint.Suggestion != s {
+		t.Errorf("DecodeCheck(%q) error = %v, want *CaseHintError", swap(s), err)
+	}
+
+	s = base62.StdEncoding.EncodeUint64Check(7654320953)
+	if _, err := hinted.DecodeToUint64Check(swap(s)); !errors.As(err, &hint) || hint.Suggestion != s {
+		t.Errorf("DecodeToUint64Check(%q) error = %v, want *CaseHintError", swap(s), err)
+	}
+	if _, err := base62.StdEncoding.DecodeToUint64Check(swap(s)); err == nil || errors.As(err, &hint) {
+		t.Errorf("DecodeToUint64Check(%q) without hint error = %v, want plain error", swap(s), err)
+	}
+}
+
+func swap(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case 'a' <= c && c <= 'z':
+			b[i] = c - 'a' + 'A'
+		case 'A' <= c && c <= 'Z':
+			b[i] = c - 'A' + 'a'
+		}
+	}
+	return string(b)
+}
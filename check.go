@@ -0,0 +1,150 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+)
+
+// EncodeUint64Check encodes the unsigned integer followed by one check
+// digit computed with the Luhn mod 62 algorithm over the digit values.
+// Starting from the rightmost digit of the encoding, every other digit is
+// doubled and reduced to the sum of its two base62 digits; the check digit
+// brings the sum of all of them to a multiple of 62, or of the radix of an
+// extended encoding. The scheme detects every single mistyped character and
+// most swaps of adjacent characters.
+func (e *Encoding) EncodeUint64Check(n uint64) string {
+	answer, length := e.EncodeUint64Array(n)
+	digits := answer[:length]
+	r := uint64(e.Radix())
+	sum := luhnSum(e, digits, 2)
+	return string(digits) + string(e.digitChar(byte((r-sum%r)%r)))
+}
+
+// DecodeToUint64Check decodes a string produced by EncodeUint64Check and
+// returns an error if the check digit does not match. With digit
+// separators, the separators are removed before the check digit is
+// verified.
+func (e *Encoding) DecodeToUint64Check(s string) (uint64, error) {
+	n, err := e.decodeToUint64Check(s)
+	if err != nil {
+		return 0, e.caseHintError(s, err, func(e *Encoding, s string) error {
+			_, err := e.decodeToUint64Check(s)
+			return err
+		})
+	}
+	return n, nil
+}
+
+func (e *Encoding) decodeToUint64Check(s string) (uint64, error) {
+	digits := s
+	if e.separators {
+		var err error
+		if digits, err = stripSeparators(s); err != nil {
+			return 0, err
+		}
+	}
+	if len(digits) < 2 {
+		return 0, fmt.Errorf("too short input in decoding a checked base62 string %q", s)
+	}
+	m := e.digitMap()
+	for i := 0; i < len(digits); i++ {
+		if m[digits[i]] == 255 {
+			return 0, fmt.Errorf("invalid character %q in decoding a base62 string %q", digits[i], s)
+		}
+	}
+	if luhnSum(e, []byte(digits), 1)%uint64(e.Radix()) != 0 {
+		return 0, fmt.Errorf("check digit mismatch in decoding a base62 string %q", s)
+	}
+	return e.DecodeToUint64(digits[:len(digits)-1])
+}
+
+// luhnSum returns the Luhn mod N sum, N the radix, of the alphabet
+// characters of s, the rightmost one multiplied by factor, then alternating
+// with the other of 1 and 2.
+func luhnSum(e *Encoding, s []byte, factor uint64) uint64 {
+	m, r := e.digitMap(), uint64(e.Radix())
+	var sum uint64
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * uint64(m[s[i]])
+		sum += addend/r + addend%r
+		factor = 3 - factor
+	}
+	return sum
+}
+
+// DefaultChecksumLen is the checksum length of EncodeCheck unless
+// WithChecksumLen sets another one, 4 bytes as in Base58Check.
+const DefaultChecksumLen = 4
+
+// WithChecksumLen returns a copy of the encoding whose EncodeCheck and
+// DecodeCheck use a checksum of n bytes, from 1 to 32, or the default for
+// zero. It panics if n is out of range.
+func (e *Encoding) WithChecksumLen(n int) *Encoding {
+	if n < 0 || n > sha256.Size {
+		panic(fmt.Sprintf("base62: checksum length %d out of range [1, %d]", n, sha256.Size))
+	}
+	c := *e
+	c.checksumLen = n
+	return &c
+}
+
+func (e *Encoding) checksumSize() int {
+	if e.checksumLen == 0 {
+		return DefaultChecksumLen
+	}
+	return e.checksumLen
+}
+
+// checksum returns the first n bytes of SHA-256(SHA-256(b)).
+func checksum(b []byte, n int) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:n]
+}
+
+// EncodeCheck encodes payload followed by a checksum, the first 4 bytes
+// of its double SHA-256 digest, like Base58Check; see WithChecksumLen for
+// other lengths. Leading zero bytes of payload are always kept, the
+// canonical option does not apply.
+func (e *Encoding) EncodeCheck(payload []byte) string {
+	n := e.checksumSize()
+	buf := make([]byte, 0, len(payload)+n)
+	buf = append(buf, payload...)
+	buf = append(buf, checksum(payload, n)...)
+	return e.encode(buf)
+}
+
+// DecodeCheck decodes a string produced by EncodeCheck, verifies the
+// checksum and returns the payload without it. An error is returned if
+// the checksum does not match, which catches nearly all typos.
+func (e *Encoding) DecodeCheck(s string) ([]byte, error) {
+	payload, err := e.decodeCheck(s)
+	if err != nil {
+		return nil, e.caseHintError(s, err, func(e *Encoding, s string) error {
+			_, err := e.decodeCheck(s)
+			return err
+		})
+	}
+	return payload, nil
+}
+
+func (e *Encoding) decodeCheck(s string) ([]byte, error) {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	n := e.checksumSize()
+	if len(b) < n {
+		return nil, fmt.Errorf("missing checksum in decoding a base62 string %q", s)
+	}
+	payload, sum := b[:len(b)-n], b[len(b)-n:]
+	if !bytes.Equal(sum, checksum(payload, n)) {
+		return nil, fmt.Errorf("checksum mismatch in decoding a base62 string %q", s)
+	}
+	return payload, nil
+}
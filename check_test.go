@@ -0,0 +1,132 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestEncodeUint64Check(t *testing.T) {
+	for _, n := range []uint64{0, 1, 61, 62, 1234567, math.MaxUint64} {
+		s := base62.StdEncoding.EncodeUint64Check(n)
+		if want := base62.StdEncoding.EncodeUint64(n); s[:len(s)-1] != want {
+			t.Errorf("EncodeUint64Check(%d) = %s, want %s and a check digit", n, s, want)
+		}
+		if got, err := base62.StdEncoding.DecodeToUint64Check(s); err != nil || got != n {
+			t.Errorf("DecodeToUint64Check(%s) = %d, %v, want %d", s, got, err, n)
+		}
+	}
+	alphabet := base62.StdEncoding.Alphabet()
+	for i := 0; i < 200; i++ {
+		n := rand.Uint64() >> uint(rand.Intn(64))
+		s := base62.StdEncoding.EncodeUint64Check(n)
+		// every single character substitution is caught
+		for pos := 0; pos < len(s); pos++ {
+			for _, c := range []byte(alphabet) {
+				if c == s[pos] {
+					continue
+				}
+				typo := s[:pos] + string(c) + s[pos+1:]
+				if got, err := base62.StdEncoding.DecodeToUint64Check(typo); err == nil {
+					t.Fatalf("DecodeToUint64Check(%s) = %d, typo of %s not caught", typo, got, s)
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeToUint64CheckInvalid(t *testing.T) {
+	for _, s := range []string{"", "0", "1?", "?1", strings.Repeat("z", 13)} {
+		if got, err := base62.StdEncoding.DecodeToUint64Check(s); err == nil {
+			t.Errorf("DecodeToUint64Check(%q) = %d, expected error", s, got)
+		}
+	}
+}
+
+func TestDecodeToUint64CheckSeparators(t *testing.T) {
+	enc := base62.StdEncoding.WithDigitSeparators()
+	s := enc.EncodeUint64Check(7654320953)
+	separated := s[:2] + "_" + s[2:]
+	if got, err := enc.DecodeToUint64Check(separated); err != nil || got != 7654320953 {
+		t.Errorf("DecodeToUint64Check(%s) = %d, %v, want %d", separated, got, err, 7654320953)
+	}
+	for pos := 0; pos < len(separated); pos++ {
+		if separated[pos] == '_' {
+			continue
+		}
+		c := byte('1')
+		if separated[pos] == c {
+			c = '2'
+		}
+		typo := separated[:pos] + string(c) + separated[pos+1:]
+		if got, err := enc.DecodeToUint64Check(typo); err == nil {
+			t.Errorf("DecodeToUint64Check(%s) = %d, typo of %s not caught", typo, got, separated)
+		}
+	}
+}
+
+func TestEncodeCheck(t *testing.T) {
+	for _, enc := range []*base62.Encoding{base62.StdEncoding, base62.StdEncoding.WithChecksumLen(1), base62.StdEncoding.WithChecksumLen(32)} {
+		for _, test := range stringTests {
+			in := []byte(test.in)
+			s := enc.EncodeCheck(in)
+			got, err := enc.DecodeCheck(s)
+			if err != nil || string(got) != test.in {
+				t.Errorf("DecodeCheck(%s) = %q, %v, want %q", s, got, err, test.in)
+			}
+		}
+		for _, in := range [][]byte{nil, {0}, {0, 0, 1}} {
+			got, err := enc.DecodeCheck(enc.EncodeCheck(in))
+			if err != nil || len(got) != len(in) {
+				t.Errorf("DecodeCheck(EncodeCheck(%x)) = %x, %v", in, got, err)
+			}
+		}
+	}
+	// the 4 byte checksum of the empty payload, 5df6e0e2
+	if got, want := base62.StdEncoding.EncodeCheck(nil), base62.StdEncoding.EncodeToString([]byte{0x5d, 0xf6, 0xe0, 0xe2}); got != want {
+		t.Errorf("EncodeCheck(nil) = %s, want %s", got, want)
+	}
+}
+
+func TestDecodeCheckCorrupted(t *testing.T) {
+	s := base62.StdEncoding.EncodeCheck([]byte("account 1234"))
+	alphabet := base62.StdEncoding.Alphabet()
+	for pos := 0; pos < len(s); pos++ {
+		c := alphabet[(strings.IndexByte(alphabet, s[pos])+1+pos)%62]
+		typo := s[:pos] + string(c) + s[pos+1:]
+		if got, err := base62.StdEncoding.DecodeCheck(typo); err == nil {
+			t.Errorf("DecodeCheck(%s) = %q, corruption of %s not caught", typo, got, s)
+		}
+	}
+	swapped := s[:3] + s[4:5] + s[3:4] + s[5:]
+	if got, err := base62.StdEncoding.DecodeCheck(swapped); err == nil && swapped != s {
+		t.Errorf("DecodeCheck(%s) = %q, swap not caught", swapped, got)
+	}
+	for _, bad := range []string{"", "3h7", s + "?", s[1:]} {
+		if got, err := base62.StdEncoding.DecodeCheck(bad); err == nil {
+			t.Errorf("DecodeCheck(%q) = %q, expected error", bad, got)
+		}
+	}
+	if _, err := base62.StdEncoding.WithChecksumLen(8).DecodeCheck(s); err == nil {
+		t.Errorf("DecodeCheck with another checksum length expected error")
+	}
+}
+
+func TestWithChecksumLenPanics(t *testing.T) {
+	for _, n := range []int{-1, 33} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WithChecksumLen(%d) expected to panic", n)
+				}
+			}()
+			base62.StdEncoding.WithChecksumLen(n)
+		}()
+	}
+}
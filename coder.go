@@ -0,0 +1,55 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+// A Coder encodes and decodes with an Encoding, keeping the scratch buffers
+// of the arithmetic between calls, so that each call only allocates its
+// result. Unlike an Encoding, a Coder holds mutable state and is not safe
+// for concurrent use; use one per goroutine, or a sync.Pool of them.
+type Coder struct {
+	enc   *Encoding
+	buf   []byte
+	limbs []uint64
+}
+
+// NewCoder returns a Coder for the encoding.
+func (e *Encoding) NewCoder() *Coder {
+	return &Coder{enc: e}
+}
+
+// Reset releases the scratch buffers, which keep the size of the largest
+// input seen so far.
+func (c *Coder) Reset() {
+	c.buf, c.limbs = nil, nil
+}
+
+// EncodeToString is EncodeToString of the encoding of the Coder.
+func (c *Coder) EncodeToString(b []byte) string {
+	if c.enc.canonical {
+		b = trimLeadingZeros(b)
+	}
+	if n := c.enc.EncodedLen(len(b)); cap(c.buf) < n {
+		c.buf = make([]byte, n)
+	}
+	n := c.enc.encodeToScratch(c.buf[:cap(c.buf)], b, &c.limbs)
+	return string(c.buf[:n])
+}
+
+// DecodeString is DecodeString of the encoding of the Coder. The result is
+// freshly allocated and owned by the caller.
+func (c *Coder) DecodeString(s string) ([]byte, error) {
+	limbs, err := c.enc.decodeLimbsTo(&c.limbs, nil, s)
+	if err != nil {
+		// the error as DecodeString reports it, with its options
+		return c.enc.DecodeString(s)
+	}
+	var numZeros int
+	for numZeros < len(s) && s[numZeros] == c.enc.alphabetIdx0 {
+		numZeros++
+	}
+	val := make([]byte, numZeros+limbsByteLen(limbs))
+	putLimbs(val[numZeros:], limbs)
+	return val, nil
+}
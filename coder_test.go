@@ -0,0 +1,70 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math/rand"
+	"testing"
+)
+
+func TestCoder(t *testing.T) {
+	for _, enc := range []*base62.Encoding{base62.StdEncoding, base62.StdEncoding.WithCanonicalEncode()} {
+		c := enc.NewCoder()
+		for _, test := range stringTests {
+			want := enc.EncodeToString([]byte(test.in))
+			if got := c.EncodeToString([]byte(test.in)); got != want {
+				t.Errorf("Coder.EncodeToString(%q) = %s, want %s", test.in, got, want)
+			}
+		}
+		// alternate sizes so that the buffers are both grown and reused
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 500; i++ {
+			b := make([]byte, r.Intn(200))
+			r.Read(b)
+			for j := 0; j < len(b) && j < i%4; j++ {
+				b[j] = 0
+			}
+			s := c.EncodeToString(b)
+			if want := enc.EncodeToString(b); s != want {
+				t.Fatalf("Coder.EncodeToString(%x) = %s, want %s", b, s, want)
+			}
+			got, err := c.DecodeString(s)
+			want, _ := enc.DecodeString(s)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Fatalf("Coder.DecodeString(%s) = %x, %v, want %x", s, got, err, want)
+			}
+			if i%100 == 0 {
+				c.Reset()
+			}
+		}
+		for _, test := range invalidStringTests {
+			if _, err := c.DecodeString(test.in); err == nil {
+				t.Errorf("Coder.DecodeString(%q) expected error", test.in)
+			}
+		}
+	}
+	// results do not share the scratch buffers
+	c := base62.StdEncoding.NewCoder()
+	a, _ := c.DecodeString("qMin")
+	c.DecodeString("3h7")
+	if string(a) != "abc" {
+		t.Errorf("Coder.DecodeString(qMin) = %q after another call, want abc", a)
+	}
+}
+
+func TestCoderAllocs(t *testing.T) {
+	c := base62.StdEncoding.NewCoder()
+	b := make([]byte, 32)
+	rand.Read(b)
+	s := c.EncodeToString(b)
+	if n := testing.AllocsPerRun(100, func() { c.EncodeToString(b) }); n > 1 {
+		t.Errorf("Coder.EncodeToString allocates %v times, want 1", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { c.DecodeString(s) }); n > 1 {
+		t.Errorf("Coder.DecodeString allocates %v times, want 1", n)
+	}
+}
@@ -0,0 +1,62 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// WithComplement returns a copy of the encoding with complement pairs over
+// its alphabet, the extended one included, as A-T and C-G in DNA, for
+// ReverseComplement. Each pair is
+// given in either or both directions; every alphabet character must be in
+// exactly one pair, possibly paired with itself, so that the complement is
+// its own inverse. An error is returned otherwise.
+func (e *Encoding) WithComplement(pairs map[byte]byte) (*Encoding, error) {
+	var comp [256]byte
+	var paired [256]bool
+	m := e.digitMap()
+	for a, b := range pairs {
+		for _, c := range []byte{a, b} {
+			if m[c] == 255 {
+				return nil, fmt.Errorf("complement pair %q-%q has a character outside the base62 alphabet", a, b)
+			}
+		}
+		if paired[a] && comp[a] != b || paired[b] && comp[b] != a {
+			return nil, fmt.Errorf("complement pair %q-%q conflicts with another pair", a, b)
+		}
+		comp[a], comp[b] = b, a
+		paired[a], paired[b] = true, true
+	}
+	for d := 0; d < e.Radix(); d++ {
+		if c := e.digitChar(byte(d)); !paired[c] {
+			return nil, fmt.Errorf("character %q of the base62 alphabet has no complement", c)
+		}
+	}
+	c := *e
+	c.complement = &comp
+	return &c, nil
+}
+
+// ReverseComplement returns s reversed with each character replaced by its
+// complement, see WithComplement. Applied twice it gives s back. An error
+// is returned if the encoding has no complement pairs or s has a character
+// outside the alphabet.
+func (e *Encoding) ReverseComplement(s string) (string, error) {
+	if e.complement == nil {
+		return "", fmt.Errorf("no complement pairs in the base62 encoding, see WithComplement")
+	}
+	out := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if e.digitMap()[c] == 255 {
+			return "", fmt.Errorf("invalid character %q in reverse complementing a base62 string %q", c, s)
+		}
+		if e.complement[c] == 0 {
+			// extended after WithComplement
+			return "", fmt.Errorf("character %q has no complement in reverse complementing a base62 string %q", c, s)
+		}
+		out[len(s)-1-i] = e.complement[c]
+	}
+	return string(out), nil
+}
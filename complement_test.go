@@ -0,0 +1,82 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"testing"
+)
+
+func TestReverseComplement(t *testing.T) {
+	// A-T and C-G in both cases, the other letters with their opposite
+	// case and each digit d with 9-d
+	pairs := map[byte]byte{'A': 'T', 'C': 'G', 'a': 't', 'c': 'g'}
+	for c := byte('b'); c <= 'z'; c++ {
+		switch c {
+		case 'c', 'g', 't':
+			continue
+		}
+		pairs[c] = c - 'a' + 'A'
+	}
+	for d := byte(0); d < 5; d++ {
+		pairs['0'+d] = '9' - d
+	}
+	enc, err := base62.StdEncoding.WithComplement(pairs)
+	if err != nil {
+		t.Fatalf("WithComplement() = %v", err)
+	}
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"", ""},
+		{"A", "T"},
+		{"ACGT", "ACGT"},
+		{"AACG", "CGTT"},
+		{"acgt0", "9acgt"},
+		{"bZ", "zB"},
+	}
+	for _, tt := range tests {
+		got, err := enc.ReverseComplement(tt.in)
+		if err != nil || got != tt.out {
+			t.Errorf("ReverseComplement(%q) = %q, %v, want %q", tt.in, got, err, tt.out)
+		}
+		if back, _ := enc.ReverseComplement(got); back != tt.in {
+			t.Errorf("ReverseComplement(%q) = %q, want %q back", got, back, tt.in)
+		}
+	}
+	if _, err := enc.ReverseComplement("AC?"); err == nil {
+		t.Errorf("ReverseComplement(AC?) expected error")
+	}
+	if _, err := base62.StdEncoding.ReverseComplement("AC"); err == nil {
+		t.Errorf("ReverseComplement without pairs expected error")
+	}
+}
+
+func TestWithComplementInvalid(t *testing.T) {
+	tests := []map[byte]byte{
+		nil,
+		{'A': 'T'},
+		{'A': '?'},
+		{'A': 'T', 'T': 'C'},
+	}
+	for _, pairs := range tests {
+		if _, err := base62.StdEncoding.WithComplement(pairs); err == nil {
+			t.Errorf("WithComplement(%q) expected error", pairs)
+		}
+	}
+	// every character its own complement is valid, reversing only
+	self := make(map[byte]byte)
+	for _, c := range []byte(base62.StdEncoding.Alphabet()) {
+		self[c] = c
+	}
+	enc, err := base62.StdEncoding.WithComplement(self)
+	if err != nil {
+		t.Fatalf("WithComplement(self) = %v", err)
+	}
+	if got, _ := enc.ReverseComplement("abc"); got != "cba" {
+		t.Errorf("ReverseComplement(abc) = %q, want cba", got)
+	}
+}
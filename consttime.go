@@ -0,0 +1,73 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/bits"
+)
+
+// errConstantTime is the only error of DecodeConstantTime, which does not
+// tell the invalid character nor its position.
+var errConstantTime = errors.New("invalid base62 string")
+
+// DecodeConstantTime decodes src like DecodeString, for secret inputs such
+// as capability tokens. The time taken depends only on the length of src,
+// not on its characters: every character is looked up by comparing it with
+// the whole alphabet, the entire input is processed even after an invalid
+// character, and the arithmetic runs over a number of limbs fixed by the
+// length. The error, if any, is the same for every invalid input.
+//
+// The price is speed, the decoding is quadratic in the length of src and
+// much slower than DecodeString. The length of the result still reveals
+// the magnitude of the value and the number of leading zero digits, as it
+// does for any decoding. Neither the case hint nor the other decoding
+// options apply.
+func (e *Encoding) DecodeConstantTime(src string) ([]byte, error) {
+	// 62 < 2^6, so the value has fewer than 6*len(src) bits
+	limbs := make([]uint64, (len(src)*6+63)/64)
+	invalid, nonZero, zeros := 0, 0, 0
+	for t := src; len(t) > 0; {
+		n := len(t)
+		if n > 10 {
+			n = 10
+		}
+		var g uint64
+		for i := 0; i < n; i++ {
+			v := t[i]
+			c, found := 0, 0
+			for k := 0; k < len(e.alphabet); k++ {
+				eq := subtle.ConstantTimeByteEq(v, e.alphabet[k])
+				c = subtle.ConstantTimeSelect(eq, k, c)
+				found |= eq
+			}
+			invalid |= found ^ 1
+			nonZero |= subtle.ConstantTimeByteEq(v, e.alphabetIdx0) ^ 1
+			zeros += nonZero ^ 1
+			g = g*radix + uint64(c)
+		}
+		carry := g
+		for i, l := range limbs {
+			limbs[i], carry = mulAdd(l, radixPow[n], carry)
+		}
+		t = t[n:]
+	}
+
+	// the byte length of the value is the largest one of its limbs
+	byteLen := 0
+	for i, l := range limbs {
+		n := i*8 + (bits.Len64(l)+7)/8
+		byteLen = subtle.ConstantTimeSelect(subtle.ConstantTimeByteEq(uint8(bits.Len64(l)), 0), byteLen, n)
+	}
+	buf := make([]byte, len(limbs)*8)
+	putLimbs(buf, limbs)
+	if invalid != 0 {
+		return nil, errConstantTime
+	}
+	val := make([]byte, zeros+byteLen)
+	copy(val[zeros:], buf[len(buf)-byteLen:])
+	return val, nil
+}
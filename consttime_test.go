@@ -0,0 +1,50 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/schwid/base62"
+)
+
+func TestDecodeConstantTime(t *testing.T) {
+	inputs := []string{"", "0", "00", "001", "z", "Z", "10", "ZZZZZZZZZZ", "ZZZZZZZZZZZ", "100000000000000000000"}
+	for _, test := range stringTests {
+		inputs = append(inputs, test.out)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		b := make([]byte, r.Intn(100))
+		r.Read(b)
+		inputs = append(inputs, base62.StdEncoding.EncodeToString(b))
+	}
+	for _, s := range inputs {
+		want, _ := base62.StdEncoding.DecodeString(s)
+		got, err := base62.StdEncoding.DecodeConstantTime(s)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("DecodeConstantTime(%q) = %x, %v, want %x", s, got, err, want)
+		}
+	}
+}
+
+func TestDecodeConstantTimeInvalid(t *testing.T) {
+	var msg string
+	for _, test := range invalidStringTests {
+		got, err := base62.StdEncoding.DecodeConstantTime(test.in)
+		if err == nil {
+			t.Errorf("DecodeConstantTime(%q) = %x, expected error", test.in, got)
+			continue
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		if err.Error() != msg {
+			t.Errorf("DecodeConstantTime(%q) error = %q, want the generic %q", test.in, err, msg)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// Correctable codes append four check characters to the encoding of the
+// payload. Taking the payload digits d1..dn and the prime p = 3833, the
+// check characters hold two values written as two digits each:
+//
+//	c1 = d1 + d2 + ... + dn          mod p
+//	c2 = 1*d1 + 2*d2 + ... + n*dn    mod p
+//
+// When a single payload digit j is off by e, the syndromes are e and j*e,
+// so their ratio locates the digit and the first one repairs it. A single
+// corrupted check character shows up in only one of the syndromes and
+// leaves the payload untouched.
+const (
+	correctPrime      = 3833
+	correctCheckLen   = 4
+	maxCorrectableLen = correctPrime - 1
+)
+
+// CorrectionStatus reports the outcome of DecodeCorrectable.
+type CorrectionStatus int
+
+const (
+	// StatusValid means the code was decoded as is.
+	StatusValid CorrectionStatus = iota
+	// StatusCorrected means a single wrong character was repaired.
+	StatusCorrected
+	// StatusUncorrectable means the code has more errors than can be
+	// repaired.
+	StatusUncorrectable
+)
+
+func (s CorrectionStatus) String() string {
+	switch s {
+	case StatusValid:
+		return "valid"
+	case StatusCorrected:
+		return "corrected"
+	case StatusUncorrectable:
+		return "uncorrectable"
+	}
+	return fmt.Sprintf("CorrectionStatus(%d)", int(s))
+}
+
+// EncodeCorrectable encodes b and appends check characters that allow
+// DecodeCorrectable to repair any single wrong character. It panics if
+// the encoding of b is longer than 3832 characters.
+func (e *Encoding) EncodeCorrectable(b []byte) string {
+	data := e.EncodeToString(b)
+	if len(data) > maxCorrectableLen {
+		panic(fmt.Sprintf("base62: %d characters are too long for a correctable code", len(data)))
+	}
+	c1, c2 := e.correctSums(data)
+	r := uint64(e.Radix())
+	check := []byte{
+		e.digitChar(byte(c1 / r)), e.digitChar(byte(c1 % r)),
+		e.digitChar(byte(c2 / r)), e.digitChar(byte(c2 % r)),
+	}
+	return data + string(check)
+}
+
+// DecodeCorrectable decodes a string produced by EncodeCorrectable,
+// repairing at most one wrong character. The status tells whether the code
+// was valid or repaired, so callers can decide whether to accept a repaired
+// value. Uncorrectable codes come with an error.
+func (e *Encoding) DecodeCorrectable(s string) ([]byte, CorrectionStatus, error) {
+	b, status, err := e.decodeCorrectable(s)
+	if err != nil {
+		return nil, status, e.caseHintError(s, err, func(e *Encoding, s string) error {
+			_, _, err := e.decodeCorrectable(s)
+			return err
+		})
+	}
+	return b, status, nil
+}
+
+func (e *Encoding) decodeCorrectable(s string) ([]byte, CorrectionStatus, error) {
+	if len(s) < correctCheckLen || len(s)-correctCheckLen > maxCorrectableLen {
+		return nil, StatusUncorrectable, fmt.Errorf("invalid length in decoding a correctable base62 string %q", s)
+	}
+	m, r := e.digitMap(), uint64(e.Radix())
+	for i := 0; i < len(s); i++ {
+		if m[s[i]] == 255 {
+			return nil, StatusUncorrectable, fmt.Errorf("invalid character %q in decoding a correctable base62 string %q", s[i], s)
+		}
+	}
+	data := []byte(s[:len(s)-correctCheckLen])
+	check := s[len(s)-correctCheckLen:]
+	c1 := uint64(m[check[0]])*r + uint64(m[check[1]])
+	c2 := uint64(m[check[2]])*r + uint64(m[check[3]])
+	sum1, sum2 := e.correctSums(string(data))
+	s1 := (sum1 + correctPrime - c1%correctPrime) % correctPrime
+	s2 := (sum2 + correctPrime - c2%correctPrime) % correctPrime
+
+	status := StatusValid
+	switch {
+	case c1 >= correctPrime || c2 >= correctPrime:
+		// a check value out of range was hit by the error, the other
+		// one must then confirm the payload
+		if (c1 >= correctPrime && c2 >= correctPrime) || (c1 >= correctPrime && s2 != 0) || (c2 >= correctPrime && s1 != 0) {
+			return nil, StatusUncorrectable, fmt.Errorf("uncorrectable errors in decoding a correctable base62 string %q", s)
+		}
+		status = StatusCorrected
+	case s1 == 0 && s2 == 0:
+	case s1 == 0 || s2 == 0:
+		// only a check value disagrees, the payload is intact
+		status = StatusCorrected
+	default:
+		if !e.correctDigit(data, s1, s2) {
+			return nil, StatusUncorrectable, fmt.Errorf("uncorrectable errors in decoding a correctable base62 string %q", s)
+		}
+		status = StatusCorrected
+	}
+	b, err := e.DecodeString(string(data))
+	if err != nil {
+		return nil, StatusUncorrectable, err
+	}
+	return b, status, nil
+}
+
+// correctSums returns the two check values of data.
+func (e *Encoding) correctSums(data string) (c1, c2 uint64) {
+	m := e.digitMap()
+	for i := 0; i < len(data); i++ {
+		d := uint64(m[data[i]])
+		c1 = (c1 + d) % correctPrime
+		c2 = (c2 + uint64(i+1)*d) % correctPrime
+	}
+	return c1, c2
+}
+
+// correctDigit repairs the single digit of data explained by the nonzero
+// syndromes s1 and s2, and reports whether such a digit exists.
+func (e *Encoding) correctDigit(data []byte, s1, s2 uint64) bool {
+	r := uint64(e.Radix())
+	var diff int64
+	switch {
+	case s1 < r:
+		diff = int64(s1)
+	case s1 > correctPrime-r:
+		diff = int64(s1) - correctPrime
+	default:
+		return false
+	}
+	pos := s2 * modInverse(s1, correctPrime) % correctPrime
+	if pos < 1 || pos > uint64(len(data)) {
+		return false
+	}
+	d := int64(e.digitMap()[data[pos-1]]) - diff
+	if d < 0 || d >= int64(r) {
+		return false
+	}
+	data[pos-1] = e.digitChar(byte(d))
+	return true
+}
+
+// modInverse returns the inverse of a modulo the prime p.
+func modInverse(a, p uint64) uint64 {
+	result, base := uint64(1), a%p
+	for n := p - 2; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = result * base % p
+		}
+		base = base * base % p
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+const stdAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+func TestCorrectable(t *testing.T) {
+	for _, in := range [][]byte{{}, {0}, []byte("abc"), []byte("user code 12345"), {0, 0, 0xff, 0xfe}} {
+		s := base62.StdEncoding.EncodeCorrectable(in)
+		got, status, err := base62.StdEncoding.DecodeCorrectable(s)
+		if err != nil || status != base62.StatusValid || !bytes.Equal(got, in) {
+			t.Errorf("DecodeCorrectable(%s) = %x, %v, %v, want valid %x", s, got, status, err, in)
+		}
+	}
+}
+
+func TestCorrectableSingleError(t *testing.T) {
+	for _, in := range [][]byte{{}, {0}, []byte("abc"), []byte("user code 12345")} {
+		s := base62.StdEncoding.EncodeCorrectable(in)
+		for pos := 0; pos < len(s); pos++ {
+			for _, c := range []byte(stdAlphabet) {
+				if c == s[pos] {
+					continue
+				}
+				bad := []byte(s)
+				bad[pos] = c
+				got, status, err := base62.StdEncoding.DecodeCorrectable(string(bad))
+				if err != nil || status != base62.StatusCorrected || !bytes.Equal(got, in) {
+					t.Fatalf("DecodeCorrectable(%s) = %x, %v, %v, want corrected %x", bad, got, status, err, in)
+				}
+			}
+		}
+	}
+}
+
+func TestCorrectableInvalid(t *testing.T) {
+	for _, s := range []string{"", "abc", "qMin?000", "ZZZZ"} {
+		if got, status, err := base62.StdEncoding.DecodeCorrectable(s); err == nil || status != base62.StatusUncorrectable {
+			t.Errorf("DecodeCorrectable(%s) = %x, %v, %v, want uncorrectable error", s, got, status, err)
+		}
+	}
+}
+
+func TestCorrectableDoubleError(t *testing.T) {
+	s := base62.StdEncoding.EncodeCorrectable([]byte("user code 12345"))
+	bad := []byte(s)
+	bad[0], bad[1] = '0', 'z'
+	if bad[0] == s[0] || bad[1] == s[1] {
+		t.Fatalf("test vector %s should differ in both characters from %s", bad, s)
+	}
+	got, status, err := base62.StdEncoding.DecodeCorrectable(string(bad))
+	if err == nil || status != base62.StatusUncorrectable {
+		t.Errorf("DecodeCorrectable(%s) = %x, %v, %v, want uncorrectable error", bad, got, status, err)
+	}
+}
+
+func TestCorrectionStatusString(t *testing.T) {
+	for status, want := range map[base62.CorrectionStatus]string{
+		base62.StatusValid:         "valid",
+		base62.StatusCorrected:     "corrected",
+		base62.StatusUncorrectable: "uncorrectable",
+		base62.CorrectionStatus(7): "CorrectionStatus(7)",
+	} {
+		if got := status.String(); got != want {
+			t.Errorf("CorrectionStatus(%d).String() = %s, want %s", int(status), got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// domainTagLen is the number of HMAC bytes binding a token to its domain.
+const domainTagLen = 4
+
+// EncodeWithDomain binds b to a domain so that the token only decodes with
+// DecodeWithDomain under the same domain.
+//
+// The domain is hashed into a key. A 4-byte HMAC-SHA256 tag of b under that
+// key is appended, and the whole is XORed with a keystream made of
+// SHA-256(key || counter) blocks before encoding. This separates contexts;
+// it is not encryption and must not be used to hide secrets.
+func (e *Encoding) EncodeWithDomain(domain string, b []byte) string {
+	key := domainKey(domain)
+	buf := make([]byte, len(b), len(b)+domainTagLen)
+	copy(buf, b)
+	buf = append(buf, domainTag(key, b)...)
+	xorKeystream(key, buf)
+	return e.encode(buf)
+}
+
+// DecodeWithDomain decodes a token produced by EncodeWithDomain and
+// returns an error if it was bound to a different domain.
+func (e *Encoding) DecodeWithDomain(domain string, s string) ([]byte, error) {
+	b, err := e.decodeWithDomain(domain, s)
+	if err != nil {
+		return nil, e.caseHintError(s, err, func(e *Encoding, s string) error {
+			_, err := e.decodeWithDomain(domain, s)
+			return err
+		})
+	}
+	return b, nil
+}
+
+func (e *Encoding) decodeWithDomain(domain string, s string) ([]byte, error) {
+	buf, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < domainTagLen {
+		return nil, fmt.Errorf("missing domain tag in decoding a base62 string %q", s)
+	}
+	key := domainKey(domain)
+	xorKeystream(key, buf)
+	b, tag := buf[:len(buf)-domainTagLen], buf[len(buf)-domainTagLen:]
+	if !hmac.Equal(tag, domainTag(key, b)) {
+		return nil, fmt.Errorf("domain mismatch in decoding a base62 string %q", s)
+	}
+	return b, nil
+}
+
+func domainKey(domain string) []byte {
+	sum := sha256.Sum256([]byte("base62 domain\x00" + domain))
+	return sum[:]
+}
+
+func domainTag(key, b []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(b)
+	return mac.Sum(nil)[:domainTagLen]
+}
+
+// xorKeystream XORs buf in place with SHA-256(key || counter) blocks.
+func xorKeystream(key, buf []byte) {
+	block := make([]byte, len(key)+8)
+	copy(block, key)
+	for counter := uint64(0); len(buf) > 0; counter++ {
+		binary.BigEndian.PutUint64(block[len(key):], counter)
+		ks := sha256.Sum256(block)
+		n := len(buf)
+		if n > len(ks) {
+			n = len(ks)
+		}
+		for i := 0; i < n; i++ {
+			buf[i] ^= ks[i]
+		}
+		buf = buf[n:]
+	}
+}
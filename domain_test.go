@@ -0,0 +1,30 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+func TestDomain(t *testing.T) {
+	for _, in := range [][]byte{{}, {0, 0, 1}, []byte("session 42"), bytes.Repeat([]byte{0xab}, 100)} {
+		token := base62.StdEncoding.EncodeWithDomain("invite", in)
+		got, err := base62.StdEncoding.DecodeWithDomain("invite", token)
+		if err != nil || !bytes.Equal(got, in) {
+			t.Errorf("DecodeWithDomain(invite, %s) = %x, %v, want %x", token, got, err, in)
+		}
+		if got, err := base62.StdEncoding.DecodeWithDomain("reset", token); err == nil {
+			t.Errorf("DecodeWithDomain(reset, %s) = %x, want domain mismatch", token, got)
+		}
+		if other := base62.StdEncoding.EncodeWithDomain("reset", in); other == token {
+			t.Errorf("EncodeWithDomain produces %s for both domains", token)
+		}
+	}
+	if got, err := base62.StdEncoding.DecodeWithDomain("invite", "1"); err == nil {
+		t.Errorf("DecodeWithDomain of a token without tag = %x, want error", got)
+	}
+}
@@ -0,0 +1,180 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Erasure shards carry a Reed-Solomon code over GF(256). The payload is
+// zero padded and split into k pieces, the coefficients of a polynomial,
+// and shard i holds that polynomial evaluated at x = i. Any k shards give k
+// distinct points, enough to solve for the coefficients.
+//
+// Every shard is the block encoding of the header bytes k, n and i, the
+// uvarint payload length and the shard data, so shards can be passed to
+// DecodeErasure in any order.
+
+// gfExp and gfLog are the exponent and logarithm tables of GF(256) with the
+// primitive polynomial x^8+x^4+x^3+x^2+1. gfExp is doubled to skip a modulo
+// in gfMul.
+var gfExp, gfLog = gfTables()
+
+func gfTables() (exp [510]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		exp[i+255] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	return
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[255-int(gfLog[a])]
+}
+
+// gfPow returns x^j, with 0^0 = 1.
+func gfPow(x byte, j int) byte {
+	if j == 0 {
+		return 1
+	}
+	if x == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[x])*j%255]
+}
+
+// EncodeErasure splits b into n shards such that any k of them reconstruct
+// it with DecodeErasure. The parameters must satisfy 1 <= k <= n <= 255.
+// Every shard is about len(b)/k bytes long plus a small header.
+func (e *Encoding) EncodeErasure(b []byte, n, k int) ([]string, error) {
+	if k < 1 || n < k || n > 255 {
+		return nil, fmt.Errorf("invalid erasure parameters n=%d and k=%d, want 1 <= k <= n <= 255", n, k)
+	}
+	size := (len(b) + k - 1) / k
+	data := make([]byte, size*k)
+	copy(data, b)
+
+	shards := make([]string, n)
+	for i := range shards {
+		buf := []byte{byte(k), byte(n), byte(i)}
+		buf = appendUvarint(buf, uint64(len(b)))
+		shard := make([]byte, size)
+		for j := 0; j < k; j++ {
+			c := gfPow(byte(i), j)
+			for t, v := range data[j*size : (j+1)*size] {
+				shard[t] ^= gfMul(c, v)
+			}
+		}
+		shards[i] = e.EncodeBlocks(append(buf, shard...))
+	}
+	return shards, nil
+}
+
+// DecodeErasure reconstructs the payload from shards produced by
+// EncodeErasure. Missing shards may be left out or passed as empty strings;
+// at least k distinct shards of the same set are needed.
+func (e *Encoding) DecodeErasure(shards []string) ([]byte, error) {
+	var k, n int
+	var length uint64
+	var seen [256]bool
+	var xs []byte
+	var rows [][]byte
+	for _, s := range shards {
+		if s == "" {
+			continue
+		}
+		buf, err := e.DecodeBlocks(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(buf) < 3 {
+			return nil, fmt.Errorf("truncated erasure shard %q", s)
+		}
+		sk, sn, i := int(buf[0]), int(buf[1]), int(buf[2])
+		l, w := binary.Uvarint(buf[3:])
+		if w <= 0 {
+			return nil, fmt.Errorf("truncated erasure shard %q", s)
+		}
+		if sk < 1 || sn < sk || i >= sn {
+			return nil, fmt.Errorf("invalid header in erasure shard %q", s)
+		}
+		shard := buf[3+w:]
+		if l > uint64(len(shard))*uint64(sk) || (int(l)+sk-1)/sk != len(shard) {
+			return nil, fmt.Errorf("invalid length in erasure shard %q", s)
+		}
+		if rows == nil {
+			k, n, length = sk, sn, l
+		} else if sk != k || sn != n || l != length {
+			return nil, fmt.Errorf("erasure shard %q belongs to another set", s)
+		}
+		if seen[i] || len(rows) == k {
+			continue
+		}
+		seen[i] = true
+		xs = append(xs, byte(i))
+		rows = append(rows, shard)
+	}
+	if rows == nil || len(rows) < k {
+		return nil, fmt.Errorf("not enough erasure shards, got %d, want %d", len(rows), k)
+	}
+
+	// Gauss-Jordan elimination of the Vandermonde matrix of the shard
+	// points, applying the same row operations to the shard data
+	m := make([][]byte, k)
+	for r := range m {
+		m[r] = make([]byte, k)
+		for j := range m[r] {
+			m[r][j] = gfPow(xs[r], j)
+		}
+	}
+	for col := 0; col < k; col++ {
+		p := col
+		for m[p][col] == 0 {
+			p++
+		}
+		m[p], m[col] = m[col], m[p]
+		rows[p], rows[col] = rows[col], rows[p]
+		if inv := gfInv(m[col][col]); inv != 1 {
+			for j := range m[col] {
+				m[col][j] = gfMul(inv, m[col][j])
+			}
+			for t := range rows[col] {
+				rows[col][t] = gfMul(inv, rows[col][t])
+			}
+		}
+		for r := 0; r < k; r++ {
+			f := m[r][col]
+			if r == col || f == 0 {
+				continue
+			}
+			for j := range m[r] {
+				m[r][j] ^= gfMul(f, m[col][j])
+			}
+			for t := range rows[r] {
+				rows[r][t] ^= gfMul(f, rows[col][t])
+			}
+		}
+	}
+
+	b := make([]byte, 0, len(rows[0])*k)
+	for _, row := range rows {
+		b = append(b, row...)
+	}
+	return b[:length], nil
+}
@@ -0,0 +1,93 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math/rand"
+	"testing"
+)
+
+func TestErasureSubsets(t *testing.T) {
+	payload := []byte("\x00\x00any three of these five codes restore this message")
+	shards, err := base62.StdEncoding.EncodeErasure(payload, 5, 3)
+	if err != nil {
+		t.Fatalf("EncodeErasure error = %v", err)
+	}
+	if len(shards) != 5 {
+		t.Fatalf("EncodeErasure gives %d shards, want 5", len(shards))
+	}
+	// every subset of shards, missing shards left empty
+	for mask := 0; mask < 1<<5; mask++ {
+		subset := make([]string, 5)
+		count := 0
+		for i := range shards {
+			if mask&(1<<i) != 0 {
+				subset[i] = shards[i]
+				count++
+			}
+		}
+		got, err := base62.StdEncoding.DecodeErasure(subset)
+		if count < 3 {
+			if err == nil {
+				t.Errorf("DecodeErasure(%05b) = %q, want error", mask, got)
+			}
+			continue
+		}
+		if err != nil || !bytes.Equal(got, payload) {
+			t.Errorf("DecodeErasure(%05b) = %q, %v, want %q", mask, got, err, payload)
+		}
+	}
+
+	// any order, duplicates ignored
+	got, err := base62.StdEncoding.DecodeErasure([]string{shards[4], shards[4], shards[1], shards[3]})
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("DecodeErasure(4, 4, 1, 3) = %q, %v, want %q", got, err, payload)
+	}
+}
+
+func TestErasureParameters(t *testing.T) {
+	for _, nk := range [][2]int{{1, 1}, {3, 1}, {4, 4}, {255, 2}, {20, 17}} {
+		n, k := nk[0], nk[1]
+		for _, size := range []int{0, 1, k - 1, k, 100} {
+			payload := make([]byte, size)
+			rand.Read(payload)
+			shards, err := base62.StdEncoding.EncodeErasure(payload, n, k)
+			if err != nil {
+				t.Fatalf("EncodeErasure(%d, %d) error = %v", n, k, err)
+			}
+			rand.Shuffle(len(shards), func(i, j int) { shards[i], shards[j] = shards[j], shards[i] })
+			got, err := base62.StdEncoding.DecodeErasure(shards[:k])
+			if err != nil || !bytes.Equal(got, payload) {
+				t.Errorf("DecodeErasure(n=%d, k=%d, %d bytes) = %x, %v, want %x", n, k, size, got, err, payload)
+			}
+		}
+	}
+	for _, nk := range [][2]int{{3, 0}, {2, 3}, {256, 2}, {-1, -1}} {
+		if _, err := base62.StdEncoding.EncodeErasure([]byte("x"), nk[0], nk[1]); err == nil {
+			t.Errorf("EncodeErasure(n=%d, k=%d) should fail", nk[0], nk[1])
+		}
+	}
+}
+
+func TestErasureErrors(t *testing.T) {
+	a, _ := base62.StdEncoding.EncodeErasure([]byte("first message"), 3, 2)
+	b, _ := base62.StdEncoding.EncodeErasure([]byte("second, longer message"), 3, 2)
+	for _, shards := range [][]string{
+		nil,
+		{"", ""},
+		{a[0]},
+		{a[0], a[0]},
+		{a[0], b[1]},
+		{a[0], "?"},
+		{a[0], base62.StdEncoding.EncodeBlocks([]byte{2, 3})},
+		{a[0], base62.StdEncoding.EncodeBlocks([]byte{2, 3, 3, 0})},
+	} {
+		if got, err := base62.StdEncoding.DecodeErasure(shards); err == nil {
+			t.Errorf("DecodeErasure(%q) = %q, want error", shards, got)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+// NewUnchecked exposes the unvalidated constructor to tests of the checks
+// that guard against broken alphabets.
+var NewUnchecked = newEncoding
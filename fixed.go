@@ -0,0 +1,118 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EncodeBytes8 encodes an 8-byte big-endian value. The result is identical
+// to EncodeToString(b[:]) but takes the uint64 path instead of big.Int.
+func (e *Encoding) EncodeBytes8(b [8]byte) string {
+	var zeros int
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	if e.canonical {
+		zeros = 0
+		if b == ([8]byte{}) {
+			return ""
+		}
+	}
+	prefix := strings.Repeat(string(e.alphabetIdx0), zeros)
+	if zeros == len(b) {
+		return prefix
+	}
+	return prefix + e.EncodeUint64(binary.BigEndian.Uint64(b[:]))
+}
+
+// EncodeUint64Array encodes the unsigned integer into a fixed-size array so
+// that the result can stay on the stack. The encoding occupies the first
+// length bytes of the array.
+func (e *Encoding) EncodeUint64Array(n uint64) (answer [maxUint64Len]byte, length int) {
+	if e.ext != nil {
+		return e.encodeUint64Ext(n)
+	}
+	if n == 0 {
+		answer[0] = e.alphabetIdx0
+		return answer, 1
+	}
+	i := len(answer)
+	var mod uint64
+	for n > 0 {
+		n, mod = n/radix, n%radix
+		i--
+		answer[i] = e.alphabet[mod]
+	}
+	length = copy(answer[:], answer[i:])
+	return answer, length
+}
+
+// DecodeStringFixed decodes s to exactly n bytes: the numeric value of s
+// left-padded with zero bytes. This restores the leading zero bytes of a
+// value of known length even when its encoding dropped them, as with the
+// canonical option. An error is returned when the value needs more than n
+// bytes.
+func (e *Encoding) DecodeStringFixed(s string, n int) ([]byte, error) {
+	limbs, err := e.decodeLimbs(s)
+	if err != nil {
+		return nil, err
+	}
+	if k := limbsByteLen(limbs); k > n {
+		return nil, fmt.Errorf("base62 string %q decodes to %d bytes, more than %d", s, k, n)
+	}
+	b := make([]byte, n)
+	putLimbs(b, limbs)
+	return b, nil
+}
+
+// DecodeCandidates decodes s to one of the candidate byte lengths and
+// reports which one. The length that DecodeString gives is preferred when
+// it is a candidate; otherwise the value is padded with DecodeStringFixed
+// to the smallest candidate it fits in. An error is returned when it fits
+// in none.
+func (e *Encoding) DecodeCandidates(s string, lengths []int) ([]byte, int, error) {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	sorted := append([]int(nil), lengths...)
+	sort.Ints(sorted)
+	for _, n := range sorted {
+		if n == len(b) {
+			return b, n, nil
+		}
+	}
+	for _, n := range sorted {
+		if b, err := e.DecodeStringFixed(s, n); err == nil {
+			return b, n, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("base62 string %q fits none of the lengths %v", s, lengths)
+}
+
+// LengthError is returned by DecodeFixed for input of another length than
+// the expected width.
+type LengthError struct {
+	Got  int
+	Want int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("invalid length %d of a base62 string, want %d", e.Got, e.Want)
+}
+
+// DecodeFixed decodes s like DecodeString after checking that it is exactly
+// width characters long, returning a *LengthError otherwise, so that tokens
+// issued with a fixed width are rejected before any decoding.
+func (e *Encoding) DecodeFixed(s string, width int) ([]byte, error) {
+	if len(s) != width {
+		return nil, &LengthError{Got: len(s), Want: width}
+	}
+	return e.DecodeString(s)
+}
@@ -0,0 +1,119 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/schwid/base62"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodeBytes8(t *testing.T) {
+	var inputs [][8]byte
+	inputs = append(inputs, [8]byte{}, [8]byte{0, 0, 0, 0, 0, 0, 0, 1}, [8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	for i := 0; i < 1000; i++ {
+		var b [8]byte
+		// shift right by a varying amount to get all counts of leading zero bytes
+		binary.BigEndian.PutUint64(b[:], rand.Uint64()>>(8*(i%9)))
+		inputs = append(inputs, b)
+	}
+	for _, b := range inputs {
+		if got, want := base62.StdEncoding.EncodeBytes8(b), base62.StdEncoding.EncodeToString(b[:]); got != want {
+			t.Errorf("EncodeBytes8(%x) = %s, want %s", b, got, want)
+		}
+	}
+}
+
+func TestEncodeUint64Array(t *testing.T) {
+	for _, n := range []uint64{0, 1, 61, 62, 1 << 32, math.MaxUint64, rand.Uint64()} {
+		answer, length := base62.StdEncoding.EncodeUint64Array(n)
+		if got, want := string(answer[:length]), base62.StdEncoding.EncodeUint64(n); got != want {
+			t.Errorf("EncodeUint64Array(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestEncodeUint64ArrayAllocs(t *testing.T) {
+	n := uint64(math.MaxUint64)
+	allocs := testing.AllocsPerRun(100, func() {
+		answer, length := base62.StdEncoding.EncodeUint64Array(n)
+		if length != len(answer) {
+			t.Fatalf("EncodeUint64Array(%d) length = %d, want %d", n, length, len(answer))
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("EncodeUint64Array allocates %v times, want 0", allocs)
+	}
+}
+
+func TestDecodeStringFixed(t *testing.T) {
+	canonical := base62.StdEncoding.WithCanonicalEncode()
+	for _, b := range [][]byte{{0, 0, 0, 0}, {0, 0, 1, 2}, {1, 2, 3, 4}, {0xff, 0xff, 0xff, 0xff}} {
+		s := canonical.EncodeToString(b)
+		got, err := base62.StdEncoding.DecodeStringFixed(s, 4)
+		if err != nil || !bytes.Equal(got, b) {
+			t.Errorf("DecodeStringFixed(%q, 4) = %x, %v, want %x", s, got, err, b)
+		}
+	}
+	if got, err := base62.StdEncoding.DecodeStringFixed("4gfFC3", 3); err == nil {
+		t.Errorf("DecodeStringFixed(4gfFC3, 3) = %x, want error", got)
+	}
+	if got, err := base62.StdEncoding.DecodeStringFixed("a?", 3); err == nil {
+		t.Errorf("DecodeStringFixed(a?, 3) = %x, want error", got)
+	}
+}
+
+func TestDecodeCandidates(t *testing.T) {
+	lengths := []int{32, 16, 20}
+	canonical := base62.StdEncoding.WithCanonicalEncode()
+	for _, tt := range []struct {
+		b    []byte
+		enc  *base62.Encoding
+		want int
+	}{
+		// unambiguous: the natural length is a candidate
+		{bytes.Repeat([]byte{0xab}, 16), base62.StdEncoding, 16},
+		{bytes.Repeat([]byte{0xab}, 20), base62.StdEncoding, 20},
+		{append(make([]byte, 3), bytes.Repeat([]byte{0xab}, 17)...), base62.StdEncoding, 20},
+		// ambiguous: leading zeros dropped, the smallest fitting length wins
+		{append(make([]byte, 3), bytes.Repeat([]byte{0xab}, 13)...), canonical, 16},
+		{append(make([]byte, 14), bytes.Repeat([]byte{0xab}, 18)...), canonical, 20},
+	} {
+		s := tt.enc.EncodeToString(tt.b)
+		got, n, err := base62.StdEncoding.DecodeCandidates(s, lengths)
+		if err != nil || n != tt.want || !bytes.Equal(bytes.TrimLeft(got, "\x00"), bytes.TrimLeft(tt.b, "\x00")) || len(got) != n {
+			t.Errorf("DecodeCandidates(%q) = %x, %d, %v, want length %d", s, got, n, err, tt.want)
+		}
+	}
+	s := base62.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xab}, 33))
+	if got, n, err := base62.StdEncoding.DecodeCandidates(s, lengths); err == nil {
+		t.Errorf("DecodeCandidates(%q) = %x, %d, want error", s, got, n)
+	}
+	if got, n, err := base62.StdEncoding.DecodeCandidates("a?", lengths); err == nil {
+		t.Errorf("DecodeCandidates(a?) = %x, %d, want error", got, n)
+	}
+}
+
+func TestDecodeFixed(t *testing.T) {
+	token := base62.StdEncoding.EncodeUint64Padded(1234567890, 11)
+	got, err := base62.StdEncoding.DecodeFixed(token, 11)
+	if want, _ := base62.StdEncoding.DecodeString(token); err != nil || !bytes.Equal(got, want) {
+		t.Errorf("DecodeFixed(%s, 11) = %x, %v, want %x", token, got, err, want)
+	}
+	for _, s := range []string{token[1:], token + "0", ""} {
+		_, err := base62.StdEncoding.DecodeFixed(s, 11)
+		var le *base62.LengthError
+		if !errors.As(err, &le) || le.Got != len(s) || le.Want != 11 {
+			t.Errorf("DecodeFixed(%q, 11) = %v, want LengthError{%d, 11}", s, err, len(s))
+		}
+	}
+	if _, err := base62.StdEncoding.DecodeFixed("0000000000?", 11); err == nil {
+		t.Errorf("DecodeFixed(0000000000?, 11) expected error")
+	}
+}
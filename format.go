@@ -0,0 +1,49 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[byte]func(string) ([]byte, error))
+)
+
+// RegisterFormat registers decode as the decoder of the tokens whose first
+// character, the version marker, is version. DecodeMultiFormat dispatches on
+// that marker, so tokens of historical formats keep decoding while new ones
+// are issued under a new marker. It is meant to be called from init
+// functions and panics if decode is nil or the version is already
+// registered.
+func RegisterFormat(version byte, decode func(string) ([]byte, error)) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if decode == nil {
+		panic("base62: RegisterFormat decoder is nil")
+	}
+	if _, dup := formats[version]; dup {
+		panic(fmt.Sprintf("base62: RegisterFormat called twice for version %q", version))
+	}
+	formats[version] = decode
+}
+
+// DecodeMultiFormat decodes s with the decoder registered for its first
+// character, passing it the rest of s. An error is returned for an empty
+// string or an unregistered version.
+func DecodeMultiFormat(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("missing version in decoding a base62 token")
+	}
+	formatsMu.RLock()
+	decode, ok := formats[s[0]]
+	formatsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown version %q in decoding a base62 token %q", s[0], s)
+	}
+	return decode(s[1:])
+}
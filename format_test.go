@@ -0,0 +1,57 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"testing"
+)
+
+func init() {
+	// version 1 stored the bytes as is, version 2 binds them to a domain
+	base62.RegisterFormat('1', base62.StdEncoding.DecodeString)
+	base62.RegisterFormat('2', func(s string) ([]byte, error) {
+		return base62.StdEncoding.DecodeWithDomain("tokens", s)
+	})
+}
+
+func TestDecodeMultiFormat(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"1" + base62.StdEncoding.EncodeToString([]byte("user 42")), "user 42"},
+		{"2" + base62.StdEncoding.EncodeWithDomain("tokens", []byte("user 42")), "user 42"},
+		{"1", ""},
+	}
+	for _, tt := range tests {
+		got, err := base62.DecodeMultiFormat(tt.in)
+		if err != nil || string(got) != tt.out {
+			t.Errorf("DecodeMultiFormat(%s) = %q, %v, want %q", tt.in, got, err, tt.out)
+		}
+	}
+	v1 := "1" + base62.StdEncoding.EncodeToString([]byte("user 42"))
+	for _, s := range []string{"", "3abc", "2" + v1[1:], "1?"} {
+		if got, err := base62.DecodeMultiFormat(s); err == nil {
+			t.Errorf("DecodeMultiFormat(%q) = %q, expected error", s, got)
+		}
+	}
+}
+
+func TestRegisterFormatPanics(t *testing.T) {
+	for _, register := range []func(){
+		func() { base62.RegisterFormat('1', base62.StdEncoding.DecodeString) },
+		func() { base62.RegisterFormat('9', nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterFormat expected to panic")
+				}
+			}()
+			register()
+		}()
+	}
+}
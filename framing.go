@@ -0,0 +1,154 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// EncodeSelfDescribing encodes b followed by its byte length, so that
+// DecodeSelfDescribing can restore the exact slice, leading zeros included.
+//
+// The layout is b in the block format (see BlockBytes), then len(b)
+// encoded with EncodeUint64, then a single digit holding the width of that
+// length field.
+func (e *Encoding) EncodeSelfDescribing(b []byte) string {
+	value := e.EncodeBlocks(b)
+	length := e.EncodeUint64(uint64(len(b)))
+	return value + length + string(e.alphabet[len(length)])
+}
+
+// DecodeSelfDescribing decodes a string produced by EncodeSelfDescribing.
+// The declared length must be the one of the encoded value, so a forged
+// length is rejected before anything is allocated.
+func (e *Encoding) DecodeSelfDescribing(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("missing length suffix in decoding a base62 string %q", s)
+	}
+	k := int(e.decodeMap[s[len(s)-1]])
+	if k == 0 || k > maxUint64Len || k > len(s)-1 {
+		return nil, fmt.Errorf("invalid length suffix in decoding a base62 string %q", s)
+	}
+	end := len(s) - 1 - k
+	n, err := e.DecodeToUint64(s[end : len(s)-1])
+	if err != nil {
+		return nil, err
+	}
+	value := s[:end]
+	if n > uint64(len(value))*6/8+1 || blockEncodedLen(int(n)) != len(value) {
+		return nil, fmt.Errorf("value does not match declared length %d in decoding a base62 string %q", n, s)
+	}
+	return e.DecodeBlocks(value)
+}
+
+// EncodeMap serializes m into a single token. Keys are written in sorted
+// order, each key and value prefixed by its uvarint length, so equal maps
+// always produce the same token.
+func (e *Encoding) EncodeMap(m map[string][]byte) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf []byte
+	for _, k := range keys {
+		buf = appendUvarint(buf, uint64(len(k)))
+		buf = append(buf, k...)
+		buf = appendUvarint(buf, uint64(len(m[k])))
+		buf = append(buf, m[k]...)
+	}
+	return e.encode(buf)
+}
+
+// DecodeToMap decodes a token produced by EncodeMap.
+func (e *Encoding) DecodeToMap(s string) (map[string][]byte, error) {
+	buf, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]byte)
+	for len(buf) > 0 {
+		var key, value []byte
+		if key, buf, err = readField(buf); err != nil {
+			return nil, fmt.Errorf("%v in decoding a base62 map %q", err, s)
+		}
+		if value, buf, err = readField(buf); err != nil {
+			return nil, fmt.Errorf("%v in decoding a base62 map %q", err, s)
+		}
+		if _, ok := m[string(key)]; ok {
+			return nil, fmt.Errorf("duplicate key %q in decoding a base62 map %q", key, s)
+		}
+		m[string(key)] = value
+	}
+	return m, nil
+}
+
+// EncodeUint64List encodes ns as a single token: a digit giving the width
+// of the count field, the count encoded with EncodeUint64, then every value
+// in a fixed width of 11 characters.
+func (e *Encoding) EncodeUint64List(ns []uint64) string {
+	count := e.EncodeUint64(uint64(len(ns)))
+	buf := make([]byte, 0, 1+len(count)+len(ns)*maxUint64Len)
+	buf = append(buf, e.alphabet[len(count)])
+	buf = append(buf, count...)
+	for _, n := range ns {
+		buf = e.appendPadded(buf, n, maxUint64Len)
+	}
+	return string(buf)
+}
+
+// DecodeToUint64List decodes a token produced by EncodeUint64List.
+func (e *Encoding) DecodeToUint64List(s string) ([]uint64, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("missing count in decoding a base62 list %q", s)
+	}
+	k := int(e.decodeMap[s[0]])
+	if k == 0 || k > maxUint64Len || k > len(s)-1 {
+		return nil, fmt.Errorf("invalid count in decoding a base62 list %q", s)
+	}
+	count, err := e.DecodeToUint64(s[1 : 1+k])
+	if err != nil {
+		return nil, err
+	}
+	values := s[1+k:]
+	if count > uint64(len(values)) || uint64(len(values)) != count*maxUint64Len {
+		return nil, fmt.Errorf("list of %d values does not match length in decoding a base62 list %q", count, s)
+	}
+	ns := make([]uint64, count)
+	for i := range ns {
+		if ns[i], err = e.DecodeToUint64(values[i*maxUint64Len : (i+1)*maxUint64Len]); err != nil {
+			return nil, err
+		}
+	}
+	return ns, nil
+}
+
+// appendUvarint appends the uvarint encoding of n to buf.
+func appendUvarint(buf []byte, n uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	return append(buf, tmp[:binary.PutUvarint(tmp[:], n)]...)
+}
+
+// readField splits a uvarint length-prefixed field off the front of buf.
+func readField(buf []byte) (field, rest []byte, err error) {
+	n, k := binary.Uvarint(buf)
+	if k <= 0 || n > uint64(len(buf)-k) {
+		return nil, nil, fmt.Errorf("truncated field")
+	}
+	buf = buf[k:]
+	return buf[:n:n], buf[n:], nil
+}
+
+// trimLeadingZeros returns b without its leading zero bytes.
+func trimLeadingZeros(b []byte) []byte {
+	for i, c := range b {
+		if c != 0 {
+			return b[i:]
+		}
+	}
+	return b[len(b):]
+}
@@ -0,0 +1,126 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math"
+	"testing"
+)
+
+var selfDescribingTests = [][]byte{
+	{},
+	{0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 1},
+	{0, 0, 0xff, 0, 0},
+	{1, 2, 3},
+	append(make([]byte, 300), 0x7f),
+}
+
+func TestSelfDescribing(t *testing.T) {
+	encodings := []*base62.Encoding{
+		base62.StdEncoding,
+		base62.New([]byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")),
+		base62.New([]byte("zyxwvutsrqponmlkjihgfedcbaZYXWVUTSRQPONMLKJIHGFEDCBA9876543210")),
+	}
+	for i, enc := range encodings {
+		for x, in := range selfDescribingTests {
+			s := enc.EncodeSelfDescribing(in)
+			got, err := enc.DecodeSelfDescribing(s)
+			if err != nil {
+				t.Errorf("DecodeSelfDescribing encoding #%d test #%d failed: %v", i, x, err)
+				continue
+			}
+			if !bytes.Equal(got, in) {
+				t.Errorf("DecodeSelfDescribing encoding #%d test #%d: got %x want %x", i, x, got, in)
+			}
+		}
+	}
+}
+
+func TestSelfDescribingInvalid(t *testing.T) {
+	enc := base62.StdEncoding
+	invalid := []string{"", "0", "5", "1a", "?1a1", "zz11"}
+	// forged lengths that the value cannot hold
+	for _, n := range []uint64{math.MaxUint64, 1 << 40, 4} {
+		length := enc.EncodeUint64(n)
+		invalid = append(invalid, enc.EncodeBlocks([]byte{1, 2})+length+enc.EncodeUint64(uint64(len(length))))
+	}
+	for _, s := range invalid {
+		if got, err := enc.DecodeSelfDescribing(s); err == nil {
+			t.Errorf("DecodeSelfDescribing(%q) = %x, want error", s, got)
+		}
+	}
+}
+
+func TestEncodeMap(t *testing.T) {
+	m := map[string][]byte{
+		"id":    {0, 0, 1, 2},
+		"empty": {},
+		"name":  []byte("base62"),
+		"":      {0xff},
+	}
+	s := base62.StdEncoding.EncodeMap(m)
+	if again := base62.StdEncoding.EncodeMap(m); again != s {
+		t.Errorf("EncodeMap is not deterministic: %s != %s", again, s)
+	}
+	got, err := base62.StdEncoding.DecodeToMap(s)
+	if err != nil {
+		t.Fatalf("DecodeToMap(%s) failed: %v", s, err)
+	}
+	if len(got) != len(m) {
+		t.Errorf("DecodeToMap(%s) returned %d entries, want %d", s, len(got), len(m))
+	}
+	for k, v := range m {
+		if g, ok := got[k]; !ok || !bytes.Equal(g, v) {
+			t.Errorf("DecodeToMap(%s)[%q] = %x, want %x", s, k, g, v)
+		}
+	}
+
+	if got, err := base62.StdEncoding.DecodeToMap(base62.StdEncoding.EncodeMap(nil)); err != nil || len(got) != 0 {
+		t.Errorf("DecodeToMap(EncodeMap(nil)) = %v, %v, want empty map", got, err)
+	}
+	truncated := base62.StdEncoding.EncodeToString([]byte{2, 'i', 'd', 5, 1})
+	if got, err := base62.StdEncoding.DecodeToMap(truncated); err == nil {
+		t.Errorf("DecodeToMap(%s) = %v, want error", truncated, got)
+	}
+}
+
+func TestUint64List(t *testing.T) {
+	large := make([]uint64, 1000)
+	for i := range large {
+		large[i] = uint64(i) * 0x9e3779b97f4a7c15
+	}
+	for x, ns := range [][]uint64{{}, {0}, {math.MaxUint64}, {1, 2, 3}, large} {
+		s := base62.StdEncoding.EncodeUint64List(ns)
+		got, err := base62.StdEncoding.DecodeToUint64List(s)
+		if err != nil {
+			t.Errorf("DecodeToUint64List test #%d failed: %v", x, err)
+			continue
+		}
+		if len(got) != len(ns) {
+			t.Errorf("DecodeToUint64List test #%d: got %d values want %d", x, len(got), len(ns))
+			continue
+		}
+		for i := range ns {
+			if got[i] != ns[i] {
+				t.Errorf("DecodeToUint64List test #%d: value #%d = %d want %d", x, i, got[i], ns[i])
+			}
+		}
+	}
+	if s := base62.StdEncoding.EncodeUint64List(nil); s != "10" {
+		t.Errorf("EncodeUint64List(nil) = %s, want 10", s)
+	}
+}
+
+func TestUint64ListInvalid(t *testing.T) {
+	for _, s := range []string{"", "0", "1", "11", "1100000000001x", "12" + "00000000001", "zz"} {
+		if got, err := base62.StdEncoding.DecodeToUint64List(s); err == nil {
+			t.Errorf("DecodeToUint64List(%s) = %v, want error", s, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+//go:build go1.18
+// +build go1.18
+
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/schwid/base62"
+	"math"
+	"testing"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, test := range stringTests {
+		f.Add([]byte(test.in), uint64(len(test.in)))
+	}
+	for _, test := range hexTests {
+		b, _ := hex.DecodeString(test.in)
+		f.Add(b, uint64(math.MaxUint64)>>len(b))
+	}
+	f.Add([]byte{0, 0, 0}, uint64(0))
+	f.Fuzz(func(t *testing.T, b []byte, n uint64) {
+		s := base62.StdEncoding.EncodeToString(b)
+		got, err := base62.StdEncoding.DecodeString(s)
+		if err != nil || !bytes.Equal(got, b) {
+			t.Errorf("DecodeString(EncodeToString(%x)) = %x, %v", b, got, err)
+		}
+		u := base62.StdEncoding.EncodeUint64(n)
+		if got, err := base62.StdEncoding.DecodeToUint64(u); err != nil || got != n {
+			t.Errorf("DecodeToUint64(EncodeUint64(%d)) = %d, %v", n, got, err)
+		}
+	})
+}
+
+func FuzzDecodeString(f *testing.F) {
+	for _, test := range stringTests {
+		f.Add(test.out)
+	}
+	for _, test := range invalidStringTests {
+		f.Add(test.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		b, err := base62.StdEncoding.DecodeString(s)
+		if err != nil {
+			if len(b) != 0 {
+				t.Errorf("DecodeString(%q) = %x with error %v", s, b, err)
+			}
+			return
+		}
+		// digits are unique once the leading zero digits are bytes
+		if got := base62.StdEncoding.EncodeToString(b); got != s {
+			t.Errorf("EncodeToString(DecodeString(%q)) = %q", s, got)
+		}
+		base62.StdEncoding.DecodeToUint64(s)
+	})
+}
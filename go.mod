@@ -2,6 +2,9 @@ module github.com/schwid/base62
 
 go 1.17
 
-require github.com/jessevdk/go-flags v1.5.0
+require (
+	github.com/jessevdk/go-flags v1.5.0
+	google.golang.org/protobuf v1.28.1
+)
 
 require golang.org/x/sys v0.0.0-20220928140112-f11e5e49a4ec // indirect
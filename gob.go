@@ -0,0 +1,28 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// EncodeGob serializes v with encoding/gob and encodes the bytes.
+func (e *Encoding) EncodeGob(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return "", err
+	}
+	return e.encode(buf.Bytes()), nil
+}
+
+// DecodeGob decodes s and deserializes the bytes into v with encoding/gob.
+func (e *Encoding) DecodeGob(s string, v interface{}) error {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return gob.NewDecoder(bytes.NewReader(b)).Decode(v)
+}
@@ -0,0 +1,53 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type gobSample struct {
+	ID      uint64
+	Name    string
+	Score   float64
+	Active  bool
+	Tags    []string
+	Attrs   map[string]int
+	Payload []byte
+	Created time.Time
+}
+
+func TestGob(t *testing.T) {
+	in := gobSample{
+		ID:      42,
+		Name:    "base62",
+		Score:   -1.5,
+		Active:  true,
+		Tags:    []string{"a", "b"},
+		Attrs:   map[string]int{"x": 1},
+		Payload: []byte{0, 1, 2},
+		Created: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	s, err := base62.StdEncoding.EncodeGob(in)
+	if err != nil {
+		t.Fatalf("EncodeGob failed: %v", err)
+	}
+	var out gobSample
+	if err := base62.StdEncoding.DecodeGob(s, &out); err != nil {
+		t.Fatalf("DecodeGob(%s) failed: %v", s, err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("DecodeGob(%s) = %+v, want %+v", s, out, in)
+	}
+	if _, err := base62.StdEncoding.EncodeGob(func() {}); err == nil {
+		t.Errorf("EncodeGob of a func should fail")
+	}
+	if err := base62.StdEncoding.DecodeGob("qM?n", &out); err == nil {
+		t.Errorf("DecodeGob of an invalid string should fail")
+	}
+}
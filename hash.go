@@ -0,0 +1,65 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// MaxFingerprintLen is the longest fingerprint, enough digits to hold the
+// whole SHA-256 digest.
+const MaxFingerprintLen = 43
+
+// Fingerprint returns a code of exactly length characters derived from the
+// SHA-256 digest of b. The digest is read as a big-endian integer and its
+// length least significant base62 digits are kept, so a longer fingerprint
+// has fewer collisions. It panics if length is not between 1 and
+// MaxFingerprintLen.
+func (e *Encoding) Fingerprint(b []byte, length int) string {
+	if length < 1 || length > MaxFingerprintLen {
+		panic(fmt.Sprintf("base62: fingerprint length %d out of range [1, %d]", length, MaxFingerprintLen))
+	}
+	sum := sha256.Sum256(b)
+	x := new(big.Int).SetBytes(sum[:])
+	mod := new(big.Int)
+	answer := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		x.DivMod(x, bigRadix[1], mod)
+		answer[i] = e.alphabet[mod.Int64()]
+	}
+	return string(answer)
+}
+
+// tagLen is the length of the tags of EncodeWithTag, about 65 bits.
+const tagLen = 11
+
+// EncodeWithTag returns the encoding of b together with an integrity tag,
+// the 11 character Fingerprint of b, that is the SHA-256 digest of b reduced
+// to base62. With the canonical option the tag covers b without its leading
+// zero bytes, the bytes that decoding gives back.
+func (e *Encoding) EncodeWithTag(b []byte) (encoded string, tag string) {
+	if e.canonical {
+		b = trimLeadingZeros(b)
+	}
+	return e.encode(b), e.Fingerprint(b, tagLen)
+}
+
+// VerifyTag decodes encoded and reports whether tag is the fingerprint of
+// the decoded bytes. Tags of any length from 1 to MaxFingerprintLen are
+// checked, so a suffix of a tag, the shorter fingerprint, also verifies.
+// An error is returned when encoded does not decode or tag has an invalid
+// length.
+func (e *Encoding) VerifyTag(encoded, tag string) (bool, error) {
+	if len(tag) < 1 || len(tag) > MaxFingerprintLen {
+		return false, fmt.Errorf("invalid tag length %d of base62 tag %q, want 1 to %d", len(tag), tag, MaxFingerprintLen)
+	}
+	b, err := e.DecodeString(encoded)
+	if err != nil {
+		return false, err
+	}
+	return e.Fingerprint(b, len(tag)) == tag, nil
+}
@@ -0,0 +1,102 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"fmt"
+	"github.com/schwid/base62"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	inputs := [][]byte{nil, {0}, []byte("abc"), make([]byte, 100000)}
+	for _, in := range inputs {
+		for length := 1; length <= base62.MaxFingerprintLen; length++ {
+			fp := base62.StdEncoding.Fingerprint(in, length)
+			if len(fp) != length {
+				t.Errorf("Fingerprint(%d bytes, %d) has length %d", len(in), length, len(fp))
+			}
+			if again := base62.StdEncoding.Fingerprint(in, length); again != fp {
+				t.Errorf("Fingerprint(%d bytes, %d) is not deterministic: %s != %s", len(in), length, fp, again)
+			}
+			if !base62IsValid(fp) {
+				t.Errorf("Fingerprint(%d bytes, %d) = %s has invalid characters", len(in), length, fp)
+			}
+		}
+	}
+}
+
+func TestFingerprintCollisions(t *testing.T) {
+	seen := make(map[string]int)
+	for i := 0; i < 20000; i++ {
+		fp := base62.StdEncoding.Fingerprint([]byte(fmt.Sprintf("key-%d", i)), 8)
+		if j, ok := seen[fp]; ok {
+			t.Errorf("Fingerprint collision between key-%d and key-%d: %s", i, j, fp)
+		}
+		seen[fp] = i
+	}
+}
+
+func TestFingerprintPanics(t *testing.T) {
+	for _, length := range []int{0, -1, base62.MaxFingerprintLen + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Fingerprint(_, %d) should panic", length)
+				}
+			}()
+			base62.StdEncoding.Fingerprint(nil, length)
+		}()
+	}
+}
+
+func base62IsValid(s string) bool {
+	_, err := base62.StdEncoding.DecodeString(s)
+	return err == nil
+}
+
+func TestEncodeWithTag(t *testing.T) {
+	for _, enc := range []*base62.Encoding{base62.StdEncoding, base62.StdEncoding.WithCanonicalEncode()} {
+		for _, in := range [][]byte{nil, {0, 0, 7}, []byte("a large payload, or so")} {
+			encoded, tag := enc.EncodeWithTag(in)
+			if encoded != enc.EncodeToString(in) || len(tag) != 11 {
+				t.Errorf("EncodeWithTag(%q) = %s, %s", in, encoded, tag)
+			}
+			if ok, err := enc.VerifyTag(encoded, tag); !ok || err != nil {
+				t.Errorf("VerifyTag(%s, %s) = %v, %v, want true", encoded, tag, ok, err)
+			}
+			if ok, err := enc.VerifyTag(encoded, tag[7:]); !ok || err != nil {
+				t.Errorf("VerifyTag(%s, %s) = %v, %v, want true", encoded, tag[7:], ok, err)
+			}
+			for i := range encoded {
+				if ok, err := enc.VerifyTag(tamper(encoded, i), tag); ok || err != nil {
+					t.Errorf("VerifyTag(%s, %s) of tampered data = %v, %v, want false", tamper(encoded, i), tag, ok, err)
+				}
+			}
+			for i := range tag {
+				if ok, err := enc.VerifyTag(encoded, tamper(tag, i)); ok || err != nil {
+					t.Errorf("VerifyTag(%s, %s) of a tampered tag = %v, %v, want false", encoded, tamper(tag, i), ok, err)
+				}
+			}
+		}
+	}
+	for _, tt := range [][2]string{{"a?", "abc"}, {"abc", ""}, {"abc", strings.Repeat("a", 44)}} {
+		if _, err := base62.StdEncoding.VerifyTag(tt[0], tt[1]); err == nil {
+			t.Errorf("VerifyTag(%q, %q) should fail", tt[0], tt[1])
+		}
+	}
+}
+
+// tamper replaces the character at i by another digit.
+func tamper(s string, i int) string {
+	b := []byte(s)
+	if b[i] == 'x' {
+		b[i] = 'y'
+	} else {
+		b[i] = 'x'
+	}
+	return string(b)
+}
@@ -0,0 +1,40 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"go/token"
+)
+
+// EncodeIdentifier encodes b as a valid Go identifier. When the encoding
+// of b, leading zero bytes kept, starts with a digit, is empty or is a Go
+// keyword, it is prefixed with an underscore, which counts as a letter in
+// Go and, not being an alphabet character, marks the adjustment for
+// DecodeIdentifier. The result is only an identifier for alphabets made of
+// ASCII letters and digits, such as the standard one; it panics if the
+// alphabet contains an underscore.
+func (e *Encoding) EncodeIdentifier(b []byte) string {
+	if e.digitMap()['_'] != 255 {
+		panic("base62: identifier prefix '_' is contained in the alphabet")
+	}
+	s := e.encode(b)
+	if s == "" || ('0' <= s[0] && s[0] <= '9') || token.IsKeyword(s) {
+		return "_" + s
+	}
+	return s
+}
+
+// DecodeIdentifier decodes a string produced by EncodeIdentifier, removing
+// the underscore prefix if present.
+func (e *Encoding) DecodeIdentifier(s string) ([]byte, error) {
+	if e.digitMap()['_'] != 255 {
+		return nil, fmt.Errorf("identifier prefix '_' is contained in the base62 alphabet")
+	}
+	if len(s) > 0 && s[0] == '_' {
+		s = s[1:]
+	}
+	return e.DecodeString(s)
+}
@@ -0,0 +1,55 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"go/token"
+	"math/rand"
+	"testing"
+
+	"github.com/schwid/base62"
+)
+
+func TestEncodeIdentifier(t *testing.T) {
+	inputs := [][]byte{nil, {0}, {0, 0, 1}, {9}, {10}, {61}, {0xff, 0xff}}
+	for _, test := range stringTests {
+		inputs = append(inputs, []byte(test.in))
+	}
+	// "if" is 0x046b as a number, a keyword before the adjustment.
+	inputs = append(inputs, []byte{0x04, 0x6b})
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		b := make([]byte, r.Intn(40))
+		r.Read(b)
+		inputs = append(inputs, b)
+	}
+	for _, b := range inputs {
+		for _, enc := range []*base62.Encoding{base62.StdEncoding, base62.GMPEncoding} {
+			s := enc.EncodeIdentifier(b)
+			if !token.IsIdentifier(s) {
+				t.Errorf("EncodeIdentifier(%x) = %q, not an identifier", b, s)
+			}
+			got, err := enc.DecodeIdentifier(s)
+			if err != nil || !bytes.Equal(got, b) {
+				t.Errorf("DecodeIdentifier(%q) = %x, %v, want %x", s, got, err, b)
+			}
+		}
+	}
+	if got := base62.StdEncoding.EncodeIdentifier([]byte{0x04, 0x6b}); got != "_if" {
+		t.Errorf("EncodeIdentifier(046b) = %q, want %q", got, "_if")
+	}
+	if got := base62.StdEncoding.EncodeIdentifier([]byte{10}); got != "a" {
+		t.Errorf("EncodeIdentifier(0a) = %q, want %q", got, "a")
+	}
+}
+
+func TestDecodeIdentifierInvalid(t *testing.T) {
+	for _, s := range []string{"__a", "a_", "_?"} {
+		if _, err := base62.StdEncoding.DecodeIdentifier(s); err == nil {
+			t.Errorf("DecodeIdentifier(%q) expected error", s)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// EncodeInt64Ordered encodes the signed integer in a fixed width of 11
+// characters such that string order matches numeric order, negatives
+// first. The value is mapped to offset binary by flipping the sign bit and
+// the result is left-padded with the zero digit.
+//
+// String order only follows digit order when the alphabet itself is in
+// ascending byte order, as in GMPEncoding. The standard alphabet puts
+// lowercase letters first and does not sort this way.
+func (e *Encoding) EncodeInt64Ordered(n int64) string {
+	return string(e.appendPadded(make([]byte, 0, maxUint64Len), uint64(n)^1<<63, maxUint64Len))
+}
+
+// DecodeToInt64Ordered decodes a string produced by EncodeInt64Ordered.
+func (e *Encoding) DecodeToInt64Ordered(src string) (int64, error) {
+	if len(src) != maxUint64Len {
+		return 0, fmt.Errorf("invalid length %d in decoding an ordered base62 string %q, want %d", len(src), src, maxUint64Len)
+	}
+	n, err := e.DecodeToUint64(src)
+	if err != nil {
+		return 0, err
+	}
+	return int64(n ^ 1<<63), nil
+}
+
+// EncodeUint64Padded encodes the unsigned integer left-padded with the zero
+// digit to width characters. The encoding is never truncated: a value
+// needing more than width characters is returned whole and longer. Any
+// width decodes again with DecodeToUint64, which skips the leading zero
+// digits.
+func (e *Encoding) EncodeUint64Padded(n uint64, width int) string {
+	return string(e.appendPadded(make([]byte, 0, maxUint64Len), n, width))
+}
+
+// appendPadded appends the encoding of n to dst, left-padded with the zero
+// digit to width characters.
+func (e *Encoding) appendPadded(dst []byte, n uint64, width int) []byte {
+	answer, length := e.EncodeUint64Array(n)
+	for i := length; i < width; i++ {
+		dst = append(dst, e.alphabetIdx0)
+	}
+	return append(dst, answer[:length]...)
+}
+
+// EncodeInt64 encodes the signed integer in zigzag form, 0, -1, 1, -2, 2
+// becoming 0, 1, 2, 3, 4, so that small magnitudes of either sign stay
+// short. It is not order preserving, see EncodeInt64Ordered for that.
+func (e *Encoding) EncodeInt64(n int64) string {
+	return e.EncodeUint64(uint64(n<<1) ^ uint64(n>>63))
+}
+
+// DecodeToInt64 decodes a string produced by EncodeInt64. Every zigzag value
+// up to math.MaxUint64 maps to an int64, so an overflow error is returned
+// exactly when the string exceeds the uint64 range.
+func (e *Encoding) DecodeToInt64(src string) (int64, error) {
+	u, err := e.DecodeToUint64(src)
+	if err != nil {
+		return 0, err
+	}
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+// EncodeSignedBytes encodes the sign-magnitude value of b, a big-endian
+// magnitude with leading zero bytes kept, and the sign negative. The sign
+// takes the first character: the zero digit for non-negative values, the
+// digit 1 for negative ones, and the encoding of b follows as is. A zero
+// magnitude is never negative, so the flag is dropped for it. Two's
+// complement slices must be converted to a magnitude by the caller.
+func (e *Encoding) EncodeSignedBytes(b []byte, negative bool) string {
+	sign := e.alphabetIdx0
+	if negative && len(trimLeadingZeros(b)) > 0 {
+		sign = e.alphabet[1]
+	}
+	return string(sign) + e.encode(b)
+}
+
+// DecodeToSignedBytes decodes a string produced by EncodeSignedBytes into
+// the magnitude bytes and the sign. An error is returned for a missing or
+// unknown sign character and for a negative zero.
+func (e *Encoding) DecodeToSignedBytes(s string) ([]byte, bool, error) {
+	if len(s) == 0 || (s[0] != e.alphabetIdx0 && s[0] != e.alphabet[1]) {
+		return nil, false, fmt.Errorf("missing sign in decoding a signed base62 string %q", s)
+	}
+	b, err := e.DecodeString(s[1:])
+	if err != nil {
+		return nil, false, err
+	}
+	negative := s[0] == e.alphabet[1]
+	if negative && len(trimLeadingZeros(b)) == 0 {
+		return nil, false, fmt.Errorf("negative zero in decoding a signed base62 string %q", s)
+	}
+	return b, negative, nil
+}
+
+// EncodeUUID encodes the 16 bytes of a UUID, exactly as EncodeToString of
+// its slice, in 22 characters at most. The value is divided as two 64-bit
+// words on the stack, so the result string is the only allocation.
+func (e *Encoding) EncodeUUID(b [16]byte) string {
+	src := b[:]
+	if e.canonical {
+		src = trimLeadingZeros(src)
+	}
+	var buf [22]byte
+	n := e.encodeTo(buf[:], src)
+	return string(buf[:n])
+}
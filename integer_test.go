@@ -0,0 +1,213 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestEncodeInt64Ordered(t *testing.T) {
+	values := []int64{math.MinInt64, math.MinInt64 + 1, -1 << 40, -62, -1, 0, 1, 61, 62, 1 << 40, math.MaxInt64 - 1, math.MaxInt64}
+	for i := 0; i < 1000; i++ {
+		values = append(values, int64(rand.Uint64()))
+	}
+	encoded := make([]string, len(values))
+	for i, n := range values {
+		s := base62.GMPEncoding.EncodeInt64Ordered(n)
+		if len(s) != 11 {
+			t.Errorf("EncodeInt64Ordered(%d) = %s, want 11 characters", n, s)
+		}
+		got, err := base62.GMPEncoding.DecodeToInt64Ordered(s)
+		if err != nil || got != n {
+			t.Errorf("DecodeToInt64Ordered(%s) = %d, %v, want %d", s, got, err, n)
+		}
+		encoded[i] = s
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	sort.Strings(encoded)
+	for i, s := range encoded {
+		got, _ := base62.GMPEncoding.DecodeToInt64Ordered(s)
+		if got != values[i] {
+			t.Fatalf("sorted encoding #%d decodes to %d, want %d", i, got, values[i])
+		}
+	}
+}
+
+func TestDecodeToInt64OrderedInvalid(t *testing.T) {
+	for _, s := range []string{"", "1", "000000000000", "0000000000?"} {
+		if got, err := base62.GMPEncoding.DecodeToInt64Ordered(s); err == nil {
+			t.Errorf("DecodeToInt64Ordered(%s) = %d, want error", s, got)
+		}
+	}
+}
+
+var int64Tests = []struct {
+	in  int64
+	out string
+}{
+	{0, "0"},
+	{-1, "1"},
+	{1, "2"},
+	{-30, "X"},
+	{30, "Y"},
+	{-31, "Z"},
+	{31, "10"},
+	{math.MaxInt64, "lYGhA16ahye"},
+	{math.MinInt64, "lYGhA16ahyf"},
+}
+
+func TestEncodeInt64(t *testing.T) {
+	for _, tt := range int64Tests {
+		if got := base62.StdEncoding.EncodeInt64(tt.in); got != tt.out {
+			t.Errorf("EncodeInt64(%d) = %s, want %s", tt.in, got, tt.out)
+		}
+		if got, err := base62.StdEncoding.DecodeToInt64(tt.out); err != nil || got != tt.in {
+			t.Errorf("DecodeToInt64(%s) = %d, %v, want %d", tt.out, got, err, tt.in)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		n := int64(rand.Uint64())
+		if got, err := base62.StdEncoding.DecodeToInt64(base62.StdEncoding.EncodeInt64(n)); err != nil || got != n {
+			t.Errorf("DecodeToInt64(EncodeInt64(%d)) = %d, %v", n, got, err)
+		}
+	}
+}
+
+func TestDecodeToInt64Invalid(t *testing.T) {
+	for _, src := range []string{"lYGhA16ahyg", "100000000000", "a?"} {
+		if got, err := base62.StdEncoding.DecodeToInt64(src); err == nil {
+			t.Errorf("DecodeToInt64(%s) = %d, want error", src, got)
+		}
+	}
+}
+
+func TestEncodeUint64Padded(t *testing.T) {
+	s := base62.StdEncoding.EncodeUint64Padded(5, 11)
+	if s != "00000000005" {
+		t.Errorf("EncodeUint64Padded(5, 11) = %s, want 00000000005", s)
+	}
+	if got, err := base62.StdEncoding.DecodeToUint64(s); err != nil || got != 5 {
+		t.Errorf("DecodeToUint64(%s) = %d, %v, want 5", s, got, err)
+	}
+	for _, tt := range []struct {
+		n     uint64
+		width int
+		want  string
+	}{
+		{0, 0, "0"},
+		{0, 3, "000"},
+		{62, 1, "10"},
+		{62, 2, "10"},
+		{math.MaxUint64, 5, "lYGhA16ahyf"},
+	} {
+		if got := base62.StdEncoding.EncodeUint64Padded(tt.n, tt.width); got != tt.want {
+			t.Errorf("EncodeUint64Padded(%d, %d) = %s, want %s", tt.n, tt.width, got, tt.want)
+		}
+	}
+	for _, n := range []uint64{0, 5, math.MaxUint64} {
+		for _, width := range []int{12, 20} {
+			s := base62.StdEncoding.EncodeUint64Padded(n, width)
+			if got, err := base62.StdEncoding.DecodeToUint64(s); len(s) != width || err != nil || got != n {
+				t.Errorf("DecodeToUint64(%s) = %d, %v, want %d", s, got, err, n)
+			}
+		}
+	}
+	for i := 0; i < 100; i++ {
+		n := rand.Uint64() % (1 << 40)
+		s := base62.StdEncoding.EncodeUint64Padded(n, 8)
+		if got, err := base62.StdEncoding.DecodeToUint64(s); len(s) != 8 || err != nil || got != n {
+			t.Errorf("DecodeToUint64(%s) = %d, %v, want %d", s, got, err, n)
+		}
+	}
+}
+
+func TestEncodeSignedBytes(t *testing.T) {
+	tests := []struct {
+		in       []byte
+		negative bool
+		out      string
+	}{
+		{nil, false, "0"},
+		{nil, true, "0"},
+		{[]byte{0}, false, "00"},
+		{[]byte{0, 0}, true, "000"},
+		{[]byte{1}, false, "01"},
+		{[]byte{1}, true, "11"},
+		{[]byte{0, 0, 62}, true, "10010"},
+		{[]byte{0, 0, 62}, false, "00010"},
+	}
+	for _, tt := range tests {
+		got := base62.StdEncoding.EncodeSignedBytes(tt.in, tt.negative)
+		if got != tt.out {
+			t.Errorf("EncodeSignedBytes(%x, %v) = %q, want %q", tt.in, tt.negative, got, tt.out)
+		}
+		b, negative, err := base62.StdEncoding.DecodeToSignedBytes(got)
+		if err != nil || !bytes.Equal(b, tt.in) || negative != (tt.negative && tt.out[0] == '1') {
+			t.Errorf("DecodeToSignedBytes(%q) = %x, %v, %v, want %x", got, b, negative, err, tt.in)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		b := make([]byte, rand.Intn(40))
+		rand.Read(b)
+		for j := 0; j < len(b) && j < i%4; j++ {
+			b[j] = 0
+		}
+		negative := i%2 == 1
+		s := base62.StdEncoding.EncodeSignedBytes(b, negative)
+		want := negative && bytes.Count(b, []byte{0}) < len(b)
+		got, gotNegative, err := base62.StdEncoding.DecodeToSignedBytes(s)
+		if err != nil || !bytes.Equal(got, b) || gotNegative != want {
+			t.Errorf("DecodeToSignedBytes(%q) = %x, %v, %v, want %x, %v", s, got, gotNegative, err, b, want)
+		}
+	}
+	for _, s := range []string{"", "2", "z1", "1", "100", "0?"} {
+		if b, negative, err := base62.StdEncoding.DecodeToSignedBytes(s); err == nil {
+			t.Errorf("DecodeToSignedBytes(%q) = %x, %v, expected error", s, b, negative)
+		}
+	}
+}
+
+func TestEncodeUUID(t *testing.T) {
+	canonical := base62.StdEncoding.WithCanonicalEncode()
+	inputs := [][16]byte{{}, {15: 1}, {0: 1}}
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	inputs = append(inputs, max)
+	for i := 0; i < 1000; i++ {
+		var b [16]byte
+		rand.Read(b[:])
+		for j := 0; j < i%17; j++ {
+			b[j] = 0
+		}
+		inputs = append(inputs, b)
+	}
+	for _, b := range inputs {
+		for _, enc := range []*base62.Encoding{base62.StdEncoding, canonical} {
+			if got, want := enc.EncodeUUID(b), enc.EncodeToString(b[:]); got != want {
+				t.Fatalf("EncodeUUID(%x) = %s, want %s", b, got, want)
+			}
+		}
+		if got, want := base62.StdEncoding.EncodeUUID(b), bigIntEncode(b[:]); got != want {
+			t.Fatalf("EncodeUUID(%x) = %s, want %s", b, got, want)
+		}
+	}
+	if got := base62.StdEncoding.EncodeUUID(max); len(got) != 22 {
+		t.Errorf("EncodeUUID(%x) = %s, want 22 characters", max, got)
+	}
+	// every length through the two word path
+	for n := 0; n <= 16; n++ {
+		b := bytes.Repeat([]byte{0xa5}, n)
+		if got, want := base62.StdEncoding.EncodeToString(b), bigIntEncode(b); got != want {
+			t.Errorf("EncodeToString(%x) = %s, want %s", b, got, want)
+		}
+	}
+}
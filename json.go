@@ -0,0 +1,89 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// DecodeJSONString decodes a base62 value embedded in JSON as a quoted
+// string. Escape sequences in the string are resolved before decoding.
+func (e *Encoding) DecodeJSONString(raw json.RawMessage) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("invalid JSON string in decoding a base62 value: %v", err)
+	}
+	return e.DecodeString(s)
+}
+
+// B62Bytes is a byte slice that marshals to JSON as a quoted StdEncoding
+// base62 string, and a nil slice as null, in structs, slices and maps alike.
+// Unlike Bytes, which goes through encoding.TextMarshaler, it implements the
+// JSON interfaces directly. Use JSONBytes for another encoding.
+type B62Bytes []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b B62Bytes) MarshalJSON() ([]byte, error) {
+	return JSONBytes{Bytes: b}.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves b unchanged,
+// as does an error, which is returned for anything but a string holding a
+// valid base62 value.
+func (b *B62Bytes) UnmarshalJSON(data []byte) error {
+	v := JSONBytes{Bytes: *b}
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*b = v.Bytes
+	return nil
+}
+
+// JSONBytes is B62Bytes with the encoding as a field, StdEncoding if nil.
+// The encoding is not part of the JSON value: to unmarshal with another
+// encoding, set it before calling json.Unmarshal, as in
+//
+//	v := base62.JSONBytes{Encoding: base62.GMPEncoding}
+//	err := json.Unmarshal(data, &v)
+type JSONBytes struct {
+	Bytes    []byte
+	Encoding *Encoding
+}
+
+func (b JSONBytes) encoding() *Encoding {
+	if b.Encoding == nil {
+		return StdEncoding
+	}
+	return b.Encoding
+}
+
+// MarshalJSON implements json.Marshaler. The string is quoted and escaped
+// as needed, so any alphabet of ASCII characters works; an error is
+// returned if the encoded string is not valid UTF-8.
+func (b JSONBytes) MarshalJSON() ([]byte, error) {
+	if b.Bytes == nil {
+		return []byte("null"), nil
+	}
+	s := b.encoding().EncodeToString(b.Bytes)
+	if !utf8.ValidString(s) {
+		return nil, fmt.Errorf("invalid UTF-8 in encoding a base62 value to JSON %q", s)
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, like B62Bytes.UnmarshalJSON.
+func (b *JSONBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := b.encoding().DecodeJSONString(data)
+	if err != nil {
+		return err
+	}
+	b.Bytes = v
+	return nil
+}
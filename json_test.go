@@ -0,0 +1,127 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+func TestDecodeJSONString(t *testing.T) {
+	tests := []struct {
+		raw string
+		out string
+	}{
+		{`"qMin"`, "abc"},
+		{`"q\u004din"`, "abc"},
+		{`""`, ""},
+	}
+	for x, test := range tests {
+		got, err := base62.StdEncoding.DecodeJSONString(json.RawMessage(test.raw))
+		if err != nil {
+			t.Errorf("DecodeJSONString test #%d failed: %v", x, err)
+			continue
+		}
+		if !bytes.Equal(got, []byte(test.out)) {
+			t.Errorf("DecodeJSONString(%s) = %q, want %q", test.raw, got, test.out)
+		}
+	}
+
+	var doc struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id": "3h7"}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := base62.StdEncoding.DecodeJSONString(doc.ID); err != nil || string(got) != "11" {
+		t.Errorf("DecodeJSONString(%s) = %q, %v, want %q", doc.ID, got, err, "11")
+	}
+
+	for _, raw := range []string{`qMin`, `42`, `"qM?n"`, `"qMin`} {
+		if got, err := base62.StdEncoding.DecodeJSONString(json.RawMessage(raw)); err == nil {
+			t.Errorf("DecodeJSONString(%s) = %q, want error", raw, got)
+		}
+	}
+}
+
+func TestB62Bytes(t *testing.T) {
+	type inner struct {
+		Key  base62.B62Bytes   `json:"key"`
+		Tags []base62.B62Bytes `json:"tags"`
+	}
+	type outer struct {
+		ID      base62.B62Bytes            `json:"id"`
+		Missing base62.B62Bytes            `json:"missing"`
+		Items   []inner                    `json:"items"`
+		ByName  map[string]base62.B62Bytes `json:"by_name"`
+		Ptr     *base62.B62Bytes           `json:"ptr"`
+	}
+	ptr := base62.B62Bytes{0, 0, 1}
+	in := outer{
+		ID: base62.B62Bytes("abc"),
+		Items: []inner{
+			{Key: base62.B62Bytes{0xff, 0}, Tags: []base62.B62Bytes{base62.B62Bytes("11"), {}}},
+			{Key: base62.B62Bytes{}},
+		},
+		ByName: map[string]base62.B62Bytes{"a": base62.B62Bytes(" ")},
+		Ptr:    &ptr,
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	want := `{"id":"qMin","missing":null,"items":[{"key":"gYU","tags":["3h7",""]},{"key":"","tags":null}],"by_name":{"a":"w"},"ptr":"001"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	var out outer
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	again, _ := json.Marshal(out)
+	if !bytes.Equal(again, data) {
+		t.Errorf("Marshal(Unmarshal(%s)) = %s", data, again)
+	}
+	if out.Missing != nil || out.Items[0].Tags[1] == nil {
+		t.Errorf("Unmarshal() = %#v, want nil for null only", out)
+	}
+
+	for _, bad := range []string{`{"id":"3mJr?"}`, `{"id":5}`, `{"id":"qM\u0000in"}`} {
+		if err := json.Unmarshal([]byte(bad), &out); err == nil {
+			t.Errorf("Unmarshal(%s) expected error", bad)
+		}
+	}
+}
+
+func TestJSONBytes(t *testing.T) {
+	data, err := json.Marshal(base62.JSONBytes{Bytes: []byte{61}, Encoding: base62.GMPEncoding})
+	if err != nil || string(data) != `"z"` {
+		t.Errorf("Marshal({61}) with GMPEncoding = %s, %v, want \"z\"", data, err)
+	}
+	b := base62.JSONBytes{Encoding: base62.GMPEncoding}
+	if err := json.Unmarshal([]byte(`"Z"`), &b); err != nil || !bytes.Equal(b.Bytes, []byte{35}) {
+		t.Errorf("Unmarshal(\"Z\") with GMPEncoding = %v, %v, want [35]", b.Bytes, err)
+	}
+
+	// characters that must be escaped in JSON strings
+	quoting := base62.New([]byte("\"\\0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX"))
+	in := []byte{0, 0, 1, 62, 1}
+	data, err = json.Marshal(base62.JSONBytes{Bytes: in, Encoding: quoting})
+	if err != nil || !json.Valid(data) {
+		t.Fatalf("Marshal() with quote and backslash in the alphabet = %s, %v, want valid JSON", data, err)
+	}
+	out := base62.JSONBytes{Encoding: quoting}
+	if err := json.Unmarshal(data, &out); err != nil || !bytes.Equal(out.Bytes, in) {
+		t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, out.Bytes, err, in)
+	}
+
+	alphabet := []byte(base62.StdEncoding.Alphabet())
+	alphabet[0] = 0xff
+	if data, err := json.Marshal(base62.JSONBytes{Bytes: []byte{0}, Encoding: base62.New(alphabet)}); err == nil {
+		t.Errorf("Marshal() with a non-UTF-8 alphabet = %s, want error", data)
+	}
+}
@@ -0,0 +1,44 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxInputLen is the largest input TryEncodeToString accepts unless
+// WithMaxInputLen sets another limit.
+const DefaultMaxInputLen = 64 << 20
+
+// ErrInputTooLarge is returned, wrapped with the sizes involved, by
+// TryEncodeToString for inputs above the limit of the encoding.
+var ErrInputTooLarge = errors.New("input too large for a single base62 number, use NewEncoder to stream it in the block format")
+
+// WithMaxInputLen returns a copy of the encoding whose TryEncodeToString
+// accepts inputs of up to n bytes. A negative n removes the limit and zero
+// restores DefaultMaxInputLen.
+func (e *Encoding) WithMaxInputLen(n int) *Encoding {
+	c := *e
+	c.maxInputLen = n
+	return &c
+}
+
+// TryEncodeToString is EncodeToString guarded against huge inputs. The
+// whole input is a single number, so encoding takes time quadratic in its
+// length and memory for the whole output at once: a gigabyte would not
+// finish in any useful time. Above the limit of the encoding, see
+// WithMaxInputLen, an error wrapping ErrInputTooLarge is returned instead.
+// The stream encoder of NewEncoder handles any size in constant memory.
+func (e *Encoding) TryEncodeToString(b []byte) (string, error) {
+	limit := e.maxInputLen
+	if limit == 0 {
+		limit = DefaultMaxInputLen
+	}
+	if limit > 0 && len(b) > limit {
+		return "", fmt.Errorf("%w: %d bytes, the limit is %d", ErrInputTooLarge, len(b), limit)
+	}
+	return e.EncodeToString(b), nil
+}
@@ -0,0 +1,41 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"errors"
+	"github.com/schwid/base62"
+	"strings"
+	"testing"
+)
+
+func TestTryEncodeToString(t *testing.T) {
+	for _, test := range stringTests {
+		got, err := base62.StdEncoding.TryEncodeToString([]byte(test.in))
+		if err != nil || got != test.out {
+			t.Errorf("TryEncodeToString(%q) = %q, %v, want %q", test.in, got, err, test.out)
+		}
+	}
+	limited := base62.StdEncoding.WithMaxInputLen(16)
+	if got, err := limited.TryEncodeToString(make([]byte, 16)); err != nil || got != strings.Repeat("0", 16) {
+		t.Errorf("TryEncodeToString(16 bytes) = %q, %v, want 16 zero digits", got, err)
+	}
+	_, err := limited.TryEncodeToString(make([]byte, 17))
+	if !errors.Is(err, base62.ErrInputTooLarge) {
+		t.Fatalf("TryEncodeToString(17 bytes) = %v, want ErrInputTooLarge", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "17") || !strings.Contains(msg, "NewEncoder") {
+		t.Errorf("TryEncodeToString(17 bytes) error = %q, want the size and the stream encoder named", msg)
+	}
+	if _, err := base62.StdEncoding.TryEncodeToString(make([]byte, base62.DefaultMaxInputLen+1)); !errors.Is(err, base62.ErrInputTooLarge) {
+		t.Errorf("TryEncodeToString(DefaultMaxInputLen+1 bytes) = %v, want ErrInputTooLarge", err)
+	}
+	if _, err := limited.WithMaxInputLen(-1).TryEncodeToString(make([]byte, 1000)); err != nil {
+		t.Errorf("TryEncodeToString without limit = %v", err)
+	}
+	if _, err := limited.WithMaxInputLen(0).TryEncodeToString(make([]byte, 1000)); err != nil {
+		t.Errorf("TryEncodeToString with the default limit = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"bufio"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// DecodeLines decodes every line read from r. The two returned slices are
+// parallel: errs[i] is nil when results[i] holds the decoded line i.
+// Surrounding whitespace on a line is ignored. A read error, if any, is
+// reported as an extra trailing entry with a nil result.
+func (e *Encoding) DecodeLines(r io.Reader) (results [][]byte, errs []error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		res, err := e.DecodeString(strings.TrimSpace(scanner.Text()))
+		results = append(results, res)
+		errs = append(errs, err)
+	}
+	if err := scanner.Err(); err != nil {
+		results = append(results, nil)
+		errs = append(errs, err)
+	}
+	return results, errs
+}
+
+// DecodeLinesParallel is DecodeLines with the lines decoded by workers
+// goroutines, GOMAXPROCS if workers is less than 1. The lines are read
+// first, so r is consumed entirely before decoding starts; the results are
+// in line order and identical to those of DecodeLines.
+func (e *Encoding) DecodeLinesParallel(r io.Reader, workers int) (results [][]byte, errs []error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+	results = make([][]byte, len(lines))
+	errs = make([]error, len(lines))
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(lines) {
+		workers = len(lines)
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i], errs[i] = e.DecodeString(lines[i])
+			}
+		}()
+	}
+	for i := range lines {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	if err := scanner.Err(); err != nil {
+		results = append(results, nil)
+		errs = append(errs, err)
+	}
+	return results, errs
+}
@@ -0,0 +1,66 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"strings"
+	"testing"
+)
+
+func TestDecodeLines(t *testing.T) {
+	input := "qMin\n3h7\r\n3mJr?\n\n  w  \n%3yxU"
+	want := []struct {
+		out string
+		err bool
+	}{
+		{"abc", false},
+		{"11", false},
+		{"", true},
+		{"", false},
+		{" ", false},
+		{"", true},
+	}
+	results, errs := base62.StdEncoding.DecodeLines(strings.NewReader(input))
+	if len(results) != len(want) || len(errs) != len(want) {
+		t.Fatalf("DecodeLines returned %d results and %d errors, want %d", len(results), len(errs), len(want))
+	}
+	for i, w := range want {
+		if (errs[i] != nil) != w.err {
+			t.Errorf("DecodeLines line #%d: error = %v, want error %v", i, errs[i], w.err)
+		}
+		if string(results[i]) != w.out {
+			t.Errorf("DecodeLines line #%d: got %q want %q", i, results[i], w.out)
+		}
+	}
+}
+
+func TestDecodeLinesParallel(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("qMin\n3h7\r\n3mJr?\n\n  w  \n%3yxU\n")
+	for _, test := range stringTests {
+		sb.WriteString(test.out + "\n")
+	}
+	for i := 0; i < 1000; i++ {
+		sb.WriteString(base62.StdEncoding.EncodeUint64(uint64(i)*0x9e3779b97f4a7c15) + "\n")
+	}
+	input := sb.String()
+	want, wantErrs := base62.StdEncoding.DecodeLines(strings.NewReader(input))
+	for _, workers := range []int{0, 1, 3, 16} {
+		results, errs := base62.StdEncoding.DecodeLinesParallel(strings.NewReader(input), workers)
+		if len(results) != len(want) || len(errs) != len(wantErrs) {
+			t.Fatalf("DecodeLinesParallel(%d) returned %d results and %d errors, want %d", workers, len(results), len(errs), len(want))
+		}
+		for i := range want {
+			if string(results[i]) != string(want[i]) || (errs[i] != nil) != (wantErrs[i] != nil) {
+				t.Errorf("DecodeLinesParallel(%d) line #%d: got %q, %v want %q, %v", workers, i, results[i], errs[i], want[i], wantErrs[i])
+			}
+		}
+	}
+	results, errs := base62.StdEncoding.DecodeLinesParallel(strings.NewReader(""), 4)
+	if len(results) != 0 || len(errs) != 0 {
+		t.Errorf("DecodeLinesParallel(\"\") returned %d results, want 0", len(results))
+	}
+}
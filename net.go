@@ -0,0 +1,48 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// Encoded widths of an address and port, the block encoding of 6 and 18
+// bytes.
+const (
+	addr4Len = 9
+	addr6Len = 25
+)
+
+// EncodeAddr packs ip and port in the block format, the address bytes
+// followed by the big endian port. IPv4 addresses, IPv4-mapped IPv6 ones
+// included, take 9 characters and IPv6 addresses 25, so the width tells
+// the version. It panics if ip is not a valid IP address.
+func (e *Encoding) EncodeAddr(ip net.IP, port uint16) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	} else if len(ip) != net.IPv6len {
+		panic(fmt.Sprintf("base62: invalid IP address %v", ip))
+	}
+	b := make([]byte, len(ip)+2)
+	copy(b, ip)
+	binary.BigEndian.PutUint16(b[len(ip):], port)
+	return e.EncodeBlocks(b)
+}
+
+// DecodeToAddr decodes a string produced by EncodeAddr. IPv4 addresses are
+// returned in their 4-byte form.
+func (e *Encoding) DecodeToAddr(s string) (net.IP, uint16, error) {
+	if len(s) != addr4Len && len(s) != addr6Len {
+		return nil, 0, fmt.Errorf("invalid length %d in decoding a base62 address %q, want %d or %d", len(s), s, addr4Len, addr6Len)
+	}
+	b, err := e.DecodeBlocks(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	n := len(b) - 2
+	return net.IP(b[:n:n]), binary.BigEndian.Uint16(b[n:]), nil
+}
@@ -0,0 +1,58 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"net"
+	"testing"
+)
+
+var addrTests = []struct {
+	ip    string
+	port  uint16
+	chars int
+}{
+	{"0.0.0.0", 0, 9},
+	{"127.0.0.1", 8080, 9},
+	{"255.255.255.255", 65535, 9},
+	{"::ffff:10.0.0.1", 443, 9},
+	{"::", 0, 25},
+	{"::1", 22, 25},
+	{"2001:db8::68", 53, 25},
+	{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", 65535, 25},
+}
+
+func TestEncodeAddr(t *testing.T) {
+	for _, tt := range addrTests {
+		ip := net.ParseIP(tt.ip)
+		s := base62.StdEncoding.EncodeAddr(ip, tt.port)
+		if len(s) != tt.chars {
+			t.Errorf("EncodeAddr(%s, %d) = %s, want %d characters", tt.ip, tt.port, s, tt.chars)
+		}
+		gotIP, gotPort, err := base62.StdEncoding.DecodeToAddr(s)
+		if err != nil || !gotIP.Equal(ip) || gotPort != tt.port {
+			t.Errorf("DecodeToAddr(%s) = %v, %d, %v, want %s, %d", s, gotIP, gotPort, err, tt.ip, tt.port)
+		}
+		if tt.chars == 9 && len(gotIP) != net.IPv4len {
+			t.Errorf("DecodeToAddr(%s) = %d-byte address, want the 4-byte form", s, len(gotIP))
+		}
+	}
+}
+
+func TestDecodeToAddrInvalid(t *testing.T) {
+	for _, s := range []string{"", "123", "1234567890", "12345678?", "zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if ip, port, err := base62.StdEncoding.DecodeToAddr(s); err == nil {
+			t.Errorf("DecodeToAddr(%s) = %v, %d, want error", s, ip, port)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EncodeAddr of an invalid IP did not panic")
+		}
+	}()
+	base62.StdEncoding.EncodeAddr(net.IP{1, 2, 3}, 80)
+}
@@ -0,0 +1,95 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// defaultOCRSubstitutions lists characters that OCR engines commonly
+// confuse, mapping each one to the character it is most often mistaken for.
+var defaultOCRSubstitutions = map[byte]byte{
+	'O': '0', '0': 'O',
+	'I': '1', '1': 'I', 'l': '1',
+	'S': '5', '5': 'S',
+	'B': '8', '8': 'B',
+	'Z': '2', '2': 'Z',
+	'G': '6', '6': 'G',
+}
+
+// DefaultOCRSubstitutions returns a copy of the table CorrectOCR uses unless
+// WithOCRSubstitutions sets another one, as a starting point for a custom
+// table.
+func DefaultOCRSubstitutions() map[byte]byte {
+	return copyOCRTable(defaultOCRSubstitutions)
+}
+
+// WithOCRSubstitutions returns a copy of the encoding that uses table in
+// CorrectOCR instead of the default substitutions. The table is copied.
+func (e *Encoding) WithOCRSubstitutions(table map[byte]byte) *Encoding {
+	c := *e
+	c.ocr = copyOCRTable(table)
+	return &c
+}
+
+func copyOCRTable(table map[byte]byte) map[byte]byte {
+	c := make(map[byte]byte, len(table))
+	for k, v := range table {
+		c[k] = v
+	}
+	return c
+}
+
+// CorrectOCR replaces characters that are not in the alphabet by their
+// substitute when the substitute is. Valid characters are never touched and
+// case is preserved, so the result is safe to pass to any decode method.
+//
+// As only characters outside the alphabet are replaced, CorrectOCR is a
+// no-op on StdEncoding, or any alphabet holding every character of the
+// table, with the default table. It helps with alphabets that leave out the
+// confusable characters, or with a table of characters outside the
+// alphabet set by WithOCRSubstitutions.
+func (e *Encoding) CorrectOCR(s string) string {
+	table := e.ocr
+	if table == nil {
+		table = defaultOCRSubstitutions
+	}
+	m := e.digitMap()
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if m[c] != 255 {
+			continue
+		}
+		if r, ok := table[c]; ok && m[r] != 255 {
+			if out == nil {
+				out = []byte(s)
+			}
+			out[i] = r
+		}
+	}
+	if out == nil {
+		return s
+	}
+	return string(out)
+}
+
+// DecodeStringURLTolerant decodes s after resolving its percent-encoded
+// sequences, so that "%41" reads as "A" for values that went through a
+// system percent-encoding defensively. A '+' is not taken for a space, as
+// in a URL path. Strings without '%' decode exactly as with DecodeString.
+// An error is returned for a malformed percent sequence.
+func (e *Encoding) DecodeStringURLTolerant(s string) ([]byte, error) {
+	if strings.IndexByte(s, '%') < 0 {
+		return e.DecodeString(s)
+	}
+	unescaped, err := url.PathUnescape(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid percent-encoding in decoding a base62 string %q: %v", s, err)
+	}
+	return e.DecodeString(unescaped)
+}
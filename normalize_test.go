@@ -0,0 +1,93 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"strings"
+	"testing"
+)
+
+// noAmbiguous leaves out 'B' and 'S' so that '8' and '5' are unambiguous.
+var noAmbiguous = base62.New([]byte("0123456789abcdefghijklmnopqrstuvwxyzACDEFGHIJKLMNOPQRTUVWXYZ-_"))
+
+func TestCorrectOCR(t *testing.T) {
+	in := []byte("scanned code 0085")
+	code := noAmbiguous.EncodeToString(in)
+	if !strings.ContainsAny(code, "58") {
+		t.Fatalf("test vector %s should contain '5' or '8'", code)
+	}
+	scanned := strings.NewReplacer("5", "S", "8", "B").Replace(code)
+	if _, err := noAmbiguous.DecodeString(scanned); err == nil {
+		t.Fatalf("DecodeString(%s) should fail before correction", scanned)
+	}
+	corrected := noAmbiguous.CorrectOCR(scanned)
+	if corrected != code {
+		t.Errorf("CorrectOCR(%s) = %s, want %s", scanned, corrected, code)
+	}
+	if got, err := noAmbiguous.DecodeString(corrected); err != nil || !bytes.Equal(got, in) {
+		t.Errorf("DecodeString(%s) = %q, %v, want %q", corrected, got, err, in)
+	}
+}
+
+func TestCorrectOCRKeepsValid(t *testing.T) {
+	// every character is valid in the standard alphabet, nothing changes
+	s := "S5B8O0Il1"
+	if got := base62.StdEncoding.CorrectOCR(s); got != s {
+		t.Errorf("CorrectOCR(%s) = %s, want unchanged", s, got)
+	}
+}
+
+func TestWithOCRSubstitutions(t *testing.T) {
+	enc := noAmbiguous.WithOCRSubstitutions(map[byte]byte{'$': '5'})
+	if got := enc.CorrectOCR("$S"); got != "5S" {
+		t.Errorf("CorrectOCR(%s) = %s, want %s", "$S", got, "5S")
+	}
+	if got := noAmbiguous.CorrectOCR("$S"); got != "$5" {
+		t.Errorf("CorrectOCR(%s) = %s, want %s", "$S", got, "$5")
+	}
+}
+
+func TestDefaultOCRSubstitutions(t *testing.T) {
+	table := base62.DefaultOCRSubstitutions()
+	if table['S'] != '5' {
+		t.Fatalf("DefaultOCRSubstitutions()['S'] = %q, want '5'", table['S'])
+	}
+	// the copy can be changed without affecting CorrectOCR
+	table['S'] = '9'
+	if got := noAmbiguous.CorrectOCR("S"); got != "5" {
+		t.Errorf("CorrectOCR(S) = %s after changing the copy, want 5", got)
+	}
+	if got := base62.DefaultOCRSubstitutions()['S']; got != '5' {
+		t.Errorf("DefaultOCRSubstitutions()['S'] = %q after changing a copy, want '5'", got)
+	}
+}
+
+func TestDecodeStringURLTolerant(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"qMin", "abc"},
+		{"%71%4D%69%6E", "abc"},
+		{"%71Min", "abc"},
+		{"qM%69n", "abc"},
+		{"qM%69%6e", "abc"},
+		{"%30%30", "\x00\x00"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := base62.StdEncoding.DecodeStringURLTolerant(tt.in)
+		if err != nil || !bytes.Equal(got, []byte(tt.out)) {
+			t.Errorf("DecodeStringURLTolerant(%q) = %q, %v, want %q", tt.in, got, err, tt.out)
+		}
+	}
+	for _, in := range []string{"qMin%", "qM%6", "qM%zzn", "%3F", "qM+in", "qM%2Bin"} {
+		if got, err := base62.StdEncoding.DecodeStringURLTolerant(in); err == nil {
+			t.Errorf("DecodeStringURLTolerant(%q) = %q, expected error", in, got)
+		}
+	}
+}
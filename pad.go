@@ -0,0 +1,82 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EncodeExactLen encodes b in exactly length characters. The value encoded
+// is b behind a 0x01 marker byte, which keeps the leading zero bytes of b,
+// and it is padded on the left with zero bytes, each one costing exactly one
+// zero digit. An error is returned when the marked value alone needs more
+// than length characters; EncodedLen(len(b)+1) characters always suffice.
+func (e *Encoding) EncodeExactLen(b []byte, length int) (string, error) {
+	marked := make([]byte, 0, len(b)+1)
+	marked = append(marked, 1)
+	marked = append(marked, b...)
+	s := e.encode(marked)
+	if len(s) > length {
+		return "", fmt.Errorf("cannot encode %d bytes in %d base62 characters, need %d", len(b), length, len(s))
+	}
+	return strings.Repeat(string(e.alphabetIdx0), length-len(s)) + s, nil
+}
+
+// DecodeExactLen decodes a string produced by EncodeExactLen, stripping the
+// padding and the marker.
+func (e *Encoding) DecodeExactLen(s string) ([]byte, error) {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	b = trimLeadingZeros(b)
+	if len(b) == 0 || b[0] != 1 {
+		return nil, fmt.Errorf("missing padding marker in decoding a base62 string %q", s)
+	}
+	return b[1:], nil
+}
+
+// EncodeNoLeadingZero encodes b so that the first character is never the
+// zero digit: the encoding of b, leading zero bytes kept, behind the digit
+// 1. The prefix costs one character and, unlike offsetting the value, works
+// for any input, so no error can occur.
+func (e *Encoding) EncodeNoLeadingZero(b []byte) string {
+	return string(e.alphabet[1]) + e.encode(b)
+}
+
+// DecodeNoLeadingZero decodes a string produced by EncodeNoLeadingZero.
+func (e *Encoding) DecodeNoLeadingZero(s string) ([]byte, error) {
+	if len(s) == 0 || s[0] != e.alphabet[1] {
+		return nil, fmt.Errorf("missing prefix %q in decoding a base62 string %q", e.alphabet[1], s)
+	}
+	return e.DecodeString(s[1:])
+}
+
+// EncodeCentered encodes b and centers the encoding in width characters
+// with pad, which must not be in the alphabet. When the padding cannot be
+// split evenly the extra pad goes to the right. An error is returned when
+// pad is in the alphabet or the encoding is longer than width.
+func (e *Encoding) EncodeCentered(b []byte, width int, pad byte) (string, error) {
+	if e.digitMap()[pad] != 255 {
+		return "", fmt.Errorf("pad %q is in the base62 alphabet", pad)
+	}
+	s := e.EncodeToString(b)
+	if len(s) > width {
+		return "", fmt.Errorf("cannot center %d base62 characters in width %d", len(s), width)
+	}
+	left := (width - len(s)) / 2
+	right := width - len(s) - left
+	return strings.Repeat(string(pad), left) + s + strings.Repeat(string(pad), right), nil
+}
+
+// DecodeCentered decodes a string produced by EncodeCentered, trimming pad
+// on both ends.
+func (e *Encoding) DecodeCentered(s string, pad byte) ([]byte, error) {
+	if e.digitMap()[pad] != 255 {
+		return nil, fmt.Errorf("pad %q is in the base62 alphabet", pad)
+	}
+	return e.DecodeString(strings.Trim(s, string(pad)))
+}
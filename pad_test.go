@@ -0,0 +1,129 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestExactLen(t *testing.T) {
+	for n := 0; n <= 40; n++ {
+		for _, fill := range []byte{0x00, 0xff, 0x5a} {
+			b := bytes.Repeat([]byte{fill}, n)
+			min := base62.StdEncoding.EncodedLen(n + 1)
+			for length := min; length <= min+3; length++ {
+				s, err := base62.StdEncoding.EncodeExactLen(b, length)
+				if err != nil || len(s) != length {
+					t.Fatalf("EncodeExactLen(%x, %d) = %q, %v, want %d characters", b, length, s, err, length)
+				}
+				got, err := base62.StdEncoding.DecodeExactLen(s)
+				if err != nil || !bytes.Equal(got, b) {
+					t.Errorf("DecodeExactLen(%q) = %x, %v, want %x", s, got, err, b)
+				}
+			}
+		}
+		// the shortest length is the marked value without padding
+		b := bytes.Repeat([]byte{0xff}, n)
+		s, _ := base62.StdEncoding.EncodeExactLen(b, 1000)
+		shortest := len(strings.TrimLeft(s, "0"))
+		if _, err := base62.StdEncoding.EncodeExactLen(b, shortest); err != nil {
+			t.Errorf("EncodeExactLen(%x, %d) error = %v", b, shortest, err)
+		}
+		if s, err := base62.StdEncoding.EncodeExactLen(b, shortest-1); err == nil {
+			t.Errorf("EncodeExactLen(%x, %d) = %q, want length error", b, shortest-1, s)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		b := make([]byte, rand.Intn(32))
+		rand.Read(b)
+		s, err := base62.StdEncoding.EncodeExactLen(b, 50)
+		if err != nil || len(s) != 50 {
+			t.Fatalf("EncodeExactLen(%x, 50) = %q, %v", b, s, err)
+		}
+		if got, err := base62.StdEncoding.DecodeExactLen(s); err != nil || !bytes.Equal(got, b) {
+			t.Errorf("DecodeExactLen(%q) = %x, %v, want %x", s, got, err, b)
+		}
+	}
+}
+
+func TestDecodeExactLenErrors(t *testing.T) {
+	for _, s := range []string{"", "000", "00z", "a?"} {
+		if got, err := base62.StdEncoding.DecodeExactLen(s); err == nil {
+			t.Errorf("DecodeExactLen(%q) = %x, want error", s, got)
+		}
+	}
+}
+
+func TestEncodeNoLeadingZero(t *testing.T) {
+	inputs := [][]byte{nil, {0}, {0, 0, 0}, {0, 1}, {1}, []byte("hello")}
+	for i := 0; i < 100; i++ {
+		b := make([]byte, rand.Intn(20))
+		rand.Read(b)
+		inputs = append(inputs, b)
+	}
+	for _, enc := range []*base62.Encoding{base62.StdEncoding, base62.StdEncoding.WithCanonicalEncode()} {
+		for _, b := range inputs {
+			s := enc.EncodeNoLeadingZero(b)
+			if s == "" || s[0] == '0' {
+				t.Errorf("EncodeNoLeadingZero(%x) = %q, want a non-zero first character", b, s)
+			}
+			got, err := enc.DecodeNoLeadingZero(s)
+			if err != nil || !bytes.Equal(got, b) && !(len(got) == 0 && len(b) == 0) {
+				t.Errorf("DecodeNoLeadingZero(%s) = %x, %v, want %x", s, got, err, b)
+			}
+		}
+	}
+	for _, s := range []string{"", "0abc", "2abc", "1a?"} {
+		if got, err := base62.StdEncoding.DecodeNoLeadingZero(s); err == nil {
+			t.Errorf("DecodeNoLeadingZero(%q) = %x, want error", s, got)
+		}
+	}
+}
+
+var centeredTests = []struct {
+	in    string
+	width int
+	want  string
+}{
+	{"", 0, ""},
+	{"", 3, "***"},
+	{" ", 1, "w"},
+	{" ", 2, "w*"},
+	{" ", 3, "*w*"},
+	{" ", 4, "*w**"},
+	{"-", 6, "**J***"},
+	{"Hello", 7, "5tp3p3V"},
+	{"Hello", 10, "*5tp3p3V**"},
+	{"Hello", 11, "**5tp3p3V**"},
+}
+
+func TestEncodeCentered(t *testing.T) {
+	for _, tt := range centeredTests {
+		s, err := base62.StdEncoding.EncodeCentered([]byte(tt.in), tt.width, '*')
+		if err != nil || s != tt.want {
+			t.Errorf("EncodeCentered(%q, %d) = %q, %v, want %q", tt.in, tt.width, s, err, tt.want)
+		}
+		got, err := base62.StdEncoding.DecodeCentered(s, '*')
+		if err != nil || string(got) != tt.in {
+			t.Errorf("DecodeCentered(%q) = %q, %v, want %q", s, got, err, tt.in)
+		}
+	}
+	if s, err := base62.StdEncoding.EncodeCentered([]byte("Hello"), 6, '*'); err == nil {
+		t.Errorf("EncodeCentered(Hello, 6) = %q, want width error", s)
+	}
+	if s, err := base62.StdEncoding.EncodeCentered([]byte("Hello"), 10, 'a'); err == nil {
+		t.Errorf("EncodeCentered with pad 'a' = %q, want error", s)
+	}
+	if _, err := base62.StdEncoding.DecodeCentered("aaa", 'a'); err == nil {
+		t.Errorf("DecodeCentered with pad 'a' should fail")
+	}
+	if _, err := base62.StdEncoding.DecodeCentered("*ab*c*", '*'); err == nil {
+		t.Errorf("DecodeCentered(*ab*c*) should fail")
+	}
+}
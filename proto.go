@@ -0,0 +1,31 @@
+//go:build proto
+// +build proto
+
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+// EncodeProto marshals m in the protobuf wire format and encodes the bytes.
+// It is only available when building with the proto tag.
+func (e *Encoding) EncodeProto(m proto.Message) (string, error) {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return e.EncodeToString(b), nil
+}
+
+// DecodeToProto decodes s and unmarshals the bytes into m.
+func (e *Encoding) DecodeToProto(s string, m proto.Message) error {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, m)
+}
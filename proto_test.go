@@ -0,0 +1,41 @@
+//go:build proto
+// +build proto
+
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"testing"
+)
+
+func TestProto(t *testing.T) {
+	msg, err := structpb.NewStruct(map[string]interface{}{
+		"id":     float64(42),
+		"name":   "base62",
+		"active": true,
+		"tags":   []interface{}{"a", "b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := base62.StdEncoding.EncodeProto(msg)
+	if err != nil {
+		t.Fatalf("EncodeProto failed: %v", err)
+	}
+	got := new(structpb.Struct)
+	if err := base62.StdEncoding.DecodeToProto(s, got); err != nil {
+		t.Fatalf("DecodeToProto(%s) failed: %v", s, err)
+	}
+	if !proto.Equal(got, msg) {
+		t.Errorf("DecodeToProto(%s) = %v, want %v", s, got, msg)
+	}
+	if err := base62.StdEncoding.DecodeToProto("qM?n", got); err == nil {
+		t.Errorf("DecodeToProto of an invalid string should fail")
+	}
+}
@@ -0,0 +1,191 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// RadixError is returned by operations that combine two encodings of
+// different radix, such as transcoding from one alphabet to another.
+type RadixError struct {
+	From int
+	To   int
+}
+
+func (e *RadixError) Error() string {
+	return fmt.Sprintf("mismatched radix: cannot combine base%d encoding with base%d encoding", e.From, e.To)
+}
+
+// Radix returns the number of digits in the encoding alphabet, 62 unless
+// the encoding was extended.
+func (e *Encoding) Radix() int {
+	if e.ext != nil {
+		return len(e.ext.alphabet)
+	}
+	return int(radix)
+}
+
+// Compatible reports whether values encoded with e can be moved to other
+// digit by digit. It returns a *RadixError naming both radixes otherwise.
+// Every cross-encoding operation checks this before touching the input.
+func (e *Encoding) Compatible(other *Encoding) error {
+	if e.Radix() != other.Radix() {
+		return &RadixError{From: e.Radix(), To: other.Radix()}
+	}
+	return nil
+}
+
+// extension is the alphabet of an extended encoding, see Extend. It is
+// never modified once built, so copies of an encoding share it.
+type extension struct {
+	alphabet  []byte
+	decodeMap [256]byte
+}
+
+// Extend returns a child encoding whose alphabet is the alphabet of e
+// followed by extra, so that its radix is e.Radix()+len(extra), base66 for
+// four extra characters. The extra characters must be distinct, not NUL
+// and not in the alphabet of e, and the radix cannot exceed 255; an error
+// is returned otherwise. The options of e are kept.
+//
+// The larger radix applies to EncodeToString, Encode, AppendEncode,
+// EncodeBigInt, DecodeString, Decode, AppendDecode, DecodeToBigInt,
+// EncodeUint64, DecodeToUint64, MatchesCharset, Valid and Transcode. They
+// take the general path of arbitrary precision arithmetic, slower than the
+// base62 one. The formats built on top of them, such as EncodeRotated,
+// EncodeCorrectable, EncodeCentered or EncodeUint64Check, handle the extra
+// characters as digits too. The formats defined for radix 62, such as the
+// block format, DigitHistogram or Sequencer codes, behave as with e,
+// rejecting the extra characters.
+func (e *Encoding) Extend(extra []byte) (*Encoding, error) {
+	if len(extra) == 0 {
+		return nil, fmt.Errorf("no characters to extend the base%d alphabet with", e.Radix())
+	}
+	alphabet := append(e.AlphabetBytes(), extra...)
+	if len(alphabet) > 255 {
+		return nil, fmt.Errorf("invalid extension of %d characters, the radix %d exceeds 255", len(extra), len(alphabet))
+	}
+	x := &extension{alphabet: alphabet}
+	x.decodeMap = *e.digitMap()
+	for i, c := range extra {
+		if c == 0 {
+			return nil, fmt.Errorf("invalid extension %q, NUL byte at %d", extra, i)
+		}
+		if x.decodeMap[c] != 255 {
+			return nil, fmt.Errorf("invalid extension %q, character %q at %d is already in the alphabet", extra, c, i)
+		}
+		x.decodeMap[c] = byte(e.Radix() + i)
+	}
+	c := *e
+	c.ext = x
+	return &c, nil
+}
+
+// digitMap returns the map from characters to digit values, 255 for
+// characters outside the alphabet, the extended one included.
+func (e *Encoding) digitMap() *[256]byte {
+	if e.ext != nil {
+		return &e.ext.decodeMap
+	}
+	return &e.decodeMap
+}
+
+// digitChar returns the character of digit d, which must be below Radix.
+func (e *Encoding) digitChar(d byte) byte {
+	if e.ext != nil {
+		return e.ext.alphabet[d]
+	}
+	return e.alphabet[d]
+}
+
+// encodeToExt is encodeTo for an extended encoding.
+func (e *Encoding) encodeToExt(dst, src []byte) int {
+	x := new(big.Int).SetBytes(src)
+	r := big.NewInt(int64(e.Radix()))
+	mod := new(big.Int)
+	n := 0
+	for x.Sign() > 0 {
+		x.DivMod(x, r, mod)
+		dst[n] = e.ext.alphabet[mod.Int64()]
+		n++
+	}
+	for _, c := range src {
+		if c != 0 {
+			break
+		}
+		dst[n] = e.alphabetIdx0
+		n++
+	}
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		dst[i], dst[j] = dst[j], dst[i]
+	}
+	return n
+}
+
+// decodeBigIntExt is DecodeToBigInt for an extended encoding.
+func (e *Encoding) decodeBigIntExt(s string) (*big.Int, error) {
+	x := new(big.Int)
+	r := big.NewInt(int64(e.Radix()))
+	d := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		c := e.ext.decodeMap[s[i]]
+		if c == 255 {
+			return nil, fmt.Errorf("invalid character %q in decoding a base%d string %q", s[i], e.Radix(), s)
+		}
+		x.Mul(x, r)
+		x.Add(x, d.SetUint64(uint64(c)))
+	}
+	return x, nil
+}
+
+// decodeLimbsExt is decodeLimbs for an extended encoding.
+func (e *Encoding) decodeLimbsExt(s string) ([]uint64, error) {
+	x, err := e.decodeBigIntExt(s)
+	if err != nil {
+		return nil, err
+	}
+	b := x.Bytes()
+	limbs := make([]uint64, (len(b)+7)/8)
+	for i := range b {
+		limbs[i/8] |= uint64(b[len(b)-1-i]) << (8 * (i % 8))
+	}
+	return limbs, nil
+}
+
+// encodeUint64Ext is EncodeUint64Array for an extended encoding.
+func (e *Encoding) encodeUint64Ext(n uint64) (answer [maxUint64Len]byte, length int) {
+	r := uint64(e.Radix())
+	i := len(answer)
+	for {
+		i--
+		answer[i] = e.ext.alphabet[n%r]
+		if n /= r; n == 0 {
+			break
+		}
+	}
+	length = copy(answer[:], answer[i:])
+	return answer, length
+}
+
+// decodeUint64Ext is decodeToUint64 for an extended encoding, after the
+// digit separators are removed.
+func (e *Encoding) decodeUint64Ext(src, digits string) (uint64, error) {
+	r := uint64(e.Radix())
+	var n uint64
+	for _, c := range []byte(digits) {
+		i := e.ext.decodeMap[c]
+		if i == 255 {
+			return 0, fmt.Errorf("invalid character %q in decoding a base%d string %q", c, r, src)
+		}
+		if n > (math.MaxUint64-uint64(i))/r {
+			return 0, fmt.Errorf("overflow in decoding a base%d string %q", r, src)
+		}
+		n = n*r + uint64(i)
+	}
+	return n, nil
+}
@@ -0,0 +1,195 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/schwid/base62"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCompatible(t *testing.T) {
+	gmp := base62.GMPEncoding
+	if err := base62.StdEncoding.Compatible(gmp); err != nil {
+		t.Errorf("Compatible() = %v, want nil", err)
+	}
+	if r := gmp.Radix(); r != 62 {
+		t.Errorf("Radix() = %d, want %d", r, 62)
+	}
+}
+
+func TestRadixError(t *testing.T) {
+	var err error = &base62.RadixError{From: 58, To: 62}
+	msg := err.Error()
+	if !strings.Contains(msg, "58") || !strings.Contains(msg, "62") {
+		t.Errorf("RadixError.Error() = %q, want both radixes named", msg)
+	}
+	var re *base62.RadixError
+	if !errors.As(err, &re) || re.From != 58 || re.To != 62 {
+		t.Errorf("errors.As(%v) = %v, want RadixError{58, 62}", err, re)
+	}
+}
+
+func TestExtend(t *testing.T) {
+	b66, err := base62.StdEncoding.Extend([]byte("-._~"))
+	if err != nil {
+		t.Fatalf("Extend() = %v", err)
+	}
+	if r := b66.Radix(); r != 66 {
+		t.Errorf("Radix() = %d, want 66", r)
+	}
+	if got, want := b66.Alphabet(), base62.StdEncoding.Alphabet()+"-._~"; got != want {
+		t.Errorf("Alphabet() = %s, want %s", got, want)
+	}
+	if base62.StdEncoding.Radix() != 62 || len(base62.StdEncoding.Alphabet()) != 62 {
+		t.Errorf("Extend() changed the parent encoding")
+	}
+	if err := b66.Compatible(base62.StdEncoding); err == nil {
+		t.Errorf("Compatible() = nil, want RadixError")
+	}
+
+	for _, tt := range []struct {
+		n   uint64
+		out string
+	}{
+		{0, "0"}, {61, "Z"}, {62, "-"}, {65, "~"}, {66, "10"}, {66*66 - 1, "~~"},
+	} {
+		if got := b66.EncodeUint64(tt.n); got != tt.out {
+			t.Errorf("EncodeUint64(%d) = %s, want %s", tt.n, got, tt.out)
+		}
+		if got, err := b66.DecodeToUint64(tt.out); err != nil || got != tt.n {
+			t.Errorf("DecodeToUint64(%s) = %d, %v, want %d", tt.out, got, err, tt.n)
+		}
+	}
+	for _, src := range []string{base62.StdEncoding.EncodeUint64(math.MaxUint64), b66.EncodeUint64(math.MaxUint64)} {
+		n, err := b66.DecodeToUint64(src + "0")
+		if err == nil {
+			t.Errorf("DecodeToUint64(%s0) = %d, want overflow", src, n)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		b := make([]byte, r.Intn(60))
+		r.Read(b)
+		for j := 0; j < len(b) && j < i%4; j++ {
+			b[j] = 0
+		}
+		s := b66.EncodeToString(b)
+		if len(s) > b66.EncodedLen(len(b)) {
+			t.Errorf("EncodeToString(%x) has length %d, above EncodedLen %d", b, len(s), b66.EncodedLen(len(b)))
+		}
+		x := new(big.Int).SetBytes(b)
+		if !b66.Valid([]byte(s)) {
+			t.Errorf("EncodeToString(%x) = %s, not a base66 string", b, s)
+		}
+		got, err := b66.DecodeString(s)
+		if err != nil || !bytes.Equal(got, b) {
+			t.Errorf("DecodeString(%s) = %x, %v, want %x", s, got, err, b)
+		}
+		if v, err := b66.DecodeToBigInt(s); err != nil || v.Cmp(x) != 0 {
+			t.Errorf("DecodeToBigInt(%s) = %v, %v, want %v", s, v, err, x)
+		}
+	}
+	// 2^64-1 in base 66 differs from base 62
+	b := bytes.Repeat([]byte{0xff}, 8)
+	if a, c := b66.EncodeToString(b), base62.StdEncoding.EncodeToString(b); a == c {
+		t.Errorf("EncodeToString(%x) = %s for both radixes", b, a)
+	}
+	if _, err := base62.StdEncoding.DecodeString("3h~"); err == nil {
+		t.Errorf("StdEncoding.DecodeString(3h~) accepted an extended character")
+	}
+	if _, err := b66.DecodeString("3h?"); err == nil {
+		t.Errorf("DecodeString(3h?) expected error")
+	}
+}
+
+func TestExtendFormats(t *testing.T) {
+	b66, _ := base62.StdEncoding.Extend([]byte("-._~"))
+	in := bytes.Repeat([]byte{0xff}, 12)
+	if s := b66.EncodeToString(in); !strings.ContainsAny(s, "-._~") {
+		t.Fatalf("EncodeToString(%x) = %s, want extended characters in the test vector", in, s)
+	}
+
+	rotated := b66.EncodeRotated(in, "k")
+	if got, err := b66.DecodeRotated(rotated, "k"); err != nil || !bytes.Equal(got, in) {
+		t.Errorf("DecodeRotated(%s) = %x, %v, want %x", rotated, got, err, in)
+	}
+
+	code := b66.EncodeCorrectable(in)
+	if got, status, err := b66.DecodeCorrectable(code); err != nil || status != base62.StatusValid || !bytes.Equal(got, in) {
+		t.Errorf("DecodeCorrectable(%s) = %x, %v, %v, want %x", code, got, status, err, in)
+	}
+	for i := 0; i < len(code); i++ {
+		typo := []byte(code)
+		typo[i] = '~'
+		if typo[i] == code[i] {
+			typo[i] = '-'
+		}
+		if got, _, err := b66.DecodeCorrectable(string(typo)); err != nil || !bytes.Equal(got, in) {
+			t.Errorf("DecodeCorrectable(%s) = %x, %v, want %x", typo, got, err, in)
+		}
+	}
+
+	if s, err := b66.EncodeCentered(in, 30, '~'); err == nil {
+		t.Errorf("EncodeCentered(~) = %s, want error for a pad in the extended alphabet", s)
+	}
+	centered, err := b66.EncodeCentered(in, 30, '*')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := b66.DecodeCentered(centered, '*'); err != nil || !bytes.Equal(got, in) {
+		t.Errorf("DecodeCentered(%s) = %x, %v, want %x", centered, got, err, in)
+	}
+
+	if got, err := b66.Concat("1", "~"); err != nil || got != "1~" {
+		t.Errorf("Concat(1, ~) = %s, %v, want 1~", got, err)
+	}
+	if got := b66.CorrectOCR("~"); got != "~" {
+		t.Errorf("CorrectOCR(~) = %s, want ~", got)
+	}
+	underscore, _ := base62.StdEncoding.Extend([]byte("_"))
+	if got, err := underscore.DecodeIdentifier("_1"); err == nil {
+		t.Errorf("DecodeIdentifier(_1) with '_' in the extended alphabet = %x, want error", got)
+	}
+}
+
+func TestExtendInvalid(t *testing.T) {
+	for _, extra := range []string{"", "a", "--", "\x00", strings.Repeat("\x80", 194)} {
+		if enc, err := base62.StdEncoding.Extend([]byte(extra)); err == nil {
+			t.Errorf("Extend(%q) = base%d, want error", extra, enc.Radix())
+		}
+	}
+	b66, _ := base62.StdEncoding.Extend([]byte("-._~"))
+	if _, err := b66.Extend([]byte("~")); err == nil {
+		t.Errorf("Extend(~) of base66 = nil, want error")
+	}
+	if b67, err := b66.Extend([]byte("+")); err != nil || b67.Radix() != 67 {
+		t.Errorf("Extend(+) of base66 = %v, want base67", err)
+	}
+}
+
+func TestTranscodeRadixError(t *testing.T) {
+	b66, _ := base62.StdEncoding.Extend([]byte("-._~"))
+	var out bytes.Buffer
+	err := base62.Transcode(base62.StdEncoding, b66, strings.NewReader("qMin"), &out)
+	var re *base62.RadixError
+	if !errors.As(err, &re) || re.From != 62 || re.To != 66 {
+		t.Errorf("Transcode() = %v, want RadixError{62, 66}", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Transcode() wrote %q before failing", out.String())
+	}
+	other, _ := base62.GMPEncoding.Extend([]byte("-._~"))
+	out.Reset()
+	if err := base62.Transcode(b66, other, strings.NewReader("a~-"), &out); err != nil || out.String() != "A~-" {
+		t.Errorf("Transcode(a~-) = %q, %v, want A~-", out.String(), err)
+	}
+}
@@ -0,0 +1,64 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// EncodeRotated encodes b and then rotates the digit at every position by
+// an amount derived from key, a Vigenère cipher over the 62 digits, or the
+// Radix digits of an extended encoding. The
+// rotations are drawn from SHA-256(key || counter) blocks, so they do not
+// repeat with the length of key. This is obfuscation, not encryption: the
+// length is not hidden and the same input and key always give the same
+// output.
+func (e *Encoding) EncodeRotated(b []byte, key string) string {
+	s := []byte(e.EncodeToString(b))
+	m, r := e.digitMap(), e.Radix()
+	rot := rotations(key, len(s), r)
+	for i, c := range s {
+		s[i] = e.digitChar(byte((int(m[c]) + int(rot[i])) % r))
+	}
+	return string(s)
+}
+
+// DecodeRotated reverses EncodeRotated. A wrong key cannot be detected and
+// gives different bytes, or an error if the result is not a valid encoding.
+func (e *Encoding) DecodeRotated(s string, key string) ([]byte, error) {
+	buf := []byte(s)
+	m, r := e.digitMap(), e.Radix()
+	rot := rotations(key, len(buf), r)
+	for i, c := range buf {
+		d := m[c]
+		if d == 255 {
+			return nil, fmt.Errorf("invalid character %q in decoding a rotated base62 string %q", c, s)
+		}
+		buf[i] = e.digitChar(byte((int(d) + r - int(rot[i])) % r))
+	}
+	return e.DecodeString(string(buf))
+}
+
+// rotations returns n rotation amounts below radix. Keystream bytes from the
+// largest multiple of radix up, 248 for base62, are skipped to keep the
+// amounts uniform.
+func rotations(key string, n, radix int) []byte {
+	limit := 256 - 256%radix
+	rot := make([]byte, 0, n)
+	block := make([]byte, len(key)+8)
+	copy(block, key)
+	for counter := uint64(0); len(rot) < n; counter++ {
+		binary.BigEndian.PutUint64(block[len(key):], counter)
+		ks := sha256.Sum256(block)
+		for _, k := range ks {
+			if int(k) < limit && len(rot) < n {
+				rot = append(rot, byte(int(k)%radix))
+			}
+		}
+	}
+	return rot
+}
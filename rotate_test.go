@@ -0,0 +1,53 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodeRotated(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		b := make([]byte, rand.Intn(100))
+		rand.Read(b)
+		s := base62.StdEncoding.EncodeRotated(b, "passphrase")
+		if len(s) != len(base62.StdEncoding.EncodeToString(b)) {
+			t.Errorf("EncodeRotated(%x) = %s, want the length of EncodeToString", b, s)
+		}
+		got, err := base62.StdEncoding.DecodeRotated(s, "passphrase")
+		if err != nil || !bytes.Equal(got, b) {
+			t.Errorf("DecodeRotated(%s) = %x, %v, want %x", s, got, err, b)
+		}
+		if len(b) > 4 {
+			if got, err := base62.StdEncoding.DecodeRotated(s, "passphrasf"); err == nil && bytes.Equal(got, b) {
+				t.Errorf("DecodeRotated(%s) with a wrong key recovered the data", s)
+			}
+		}
+	}
+}
+
+func TestEncodeRotatedPositions(t *testing.T) {
+	// a run of equal digits does not give a run of equal characters
+	s := base62.StdEncoding.EncodeRotated(make([]byte, 20), "key")
+	if s == base62.StdEncoding.EncodeToString(make([]byte, 20)) {
+		t.Fatalf("EncodeRotated did not rotate")
+	}
+	seen := map[rune]bool{}
+	for _, c := range s {
+		seen[c] = true
+	}
+	if len(seen) < 5 {
+		t.Errorf("EncodeRotated(zeros) = %s, want varying rotations", s)
+	}
+	if base62.StdEncoding.EncodeRotated([]byte("x"), "a") == base62.StdEncoding.EncodeRotated([]byte("x"), "b") {
+		t.Errorf("EncodeRotated ignores the key")
+	}
+	if _, err := base62.StdEncoding.DecodeRotated("ab?", "key"); err == nil {
+		t.Errorf("DecodeRotated(ab?) should fail")
+	}
+}
@@ -0,0 +1,42 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// Field describes a named fixed-width part of a structured token.
+type Field struct {
+	Name  string
+	Chars int
+}
+
+// DecodeSchema splits s into consecutive fields of the given widths and
+// decodes each of them with DecodeString. The widths must add up to the
+// length of s exactly.
+func (e *Encoding) DecodeSchema(s string, fields []Field) (map[string][]byte, error) {
+	total := 0
+	for _, f := range fields {
+		if f.Chars < 0 {
+			return nil, fmt.Errorf("negative width %d of field %q in decoding a base62 schema", f.Chars, f.Name)
+		}
+		total += f.Chars
+	}
+	if total != len(s) {
+		return nil, fmt.Errorf("schema expects %d characters, got %d in decoding a base62 string %q", total, len(s), s)
+	}
+	m := make(map[string][]byte, len(fields))
+	for _, f := range fields {
+		if _, ok := m[f.Name]; ok {
+			return nil, fmt.Errorf("duplicate field %q in decoding a base62 schema", f.Name)
+		}
+		b, err := e.DecodeString(s[:f.Chars])
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %v", f.Name, err)
+		}
+		m[f.Name] = b
+		s = s[f.Chars:]
+	}
+	return m, nil
+}
@@ -0,0 +1,55 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+var tokenSchema = []base62.Field{
+	{Name: "version", Chars: 1},
+	{Name: "user", Chars: 7},
+	{Name: "nonce", Chars: 4},
+}
+
+func TestDecodeSchema(t *testing.T) {
+	token := "1" + "000qMin" + "3h7w"
+	m, err := base62.StdEncoding.DecodeSchema(token, tokenSchema)
+	if err != nil {
+		t.Fatalf("DecodeSchema(%s) failed: %v", token, err)
+	}
+	want := map[string][]byte{
+		"version": {1},
+		"user":    {0, 0, 0, 'a', 'b', 'c'},
+		"nonce":   base62DecodeString("3h7w"),
+	}
+	for name, w := range want {
+		if got := m[name]; !bytes.Equal(got, w) {
+			t.Errorf("DecodeSchema(%s)[%q] = %x, want %x", token, name, got, w)
+		}
+	}
+}
+
+func TestDecodeSchemaErrors(t *testing.T) {
+	for _, token := range []string{"", "1000qMin3h7", "1000qMin3h7wx", "1000qM?n3h7w"} {
+		if m, err := base62.StdEncoding.DecodeSchema(token, tokenSchema); err == nil {
+			t.Errorf("DecodeSchema(%s) = %v, want error", token, m)
+		}
+	}
+	dup := []base62.Field{{Name: "a", Chars: 1}, {Name: "a", Chars: 1}}
+	if m, err := base62.StdEncoding.DecodeSchema("12", dup); err == nil {
+		t.Errorf("DecodeSchema with duplicate fields = %v, want error", m)
+	}
+}
+
+func base62DecodeString(s string) []byte {
+	b, err := base62.StdEncoding.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,145 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	// maxSequencerLen is the longest code of a Sequencer, 62^10 codes
+	// being the last width that fits in uint64 arithmetic.
+	maxSequencerLen = 10
+	// feistelRounds is the number of rounds of the Sequencer permutation,
+	// an even number so that the halves end in their original places.
+	feistelRounds = 8
+)
+
+// A Sequencer turns an incrementing counter into unique codes that do not
+// look sequential. Counter values are numbered within each code width,
+// starting with minLen characters: the first 62^minLen values give codes of
+// minLen characters, the next 62^(minLen+1) codes one character longer,
+// and so on up to 10 characters. Within a width the index is shuffled by a
+// Feistel network over the 62^width codes, a bijection keyed by the seed,
+// so codes never collide and Parse gives the counter value back.
+//
+// The shuffling hides the order from a casual look only; it is not
+// encryption, and the seed can be recovered from enough codes. A Sequencer
+// is safe for concurrent use. The codes are base62 even for an extended
+// encoding, whose extra characters Parse rejects.
+type Sequencer struct {
+	enc    *Encoding
+	minLen int
+	keys   [feistelRounds]uint64
+	next   uint64
+}
+
+// NewSequencer returns a Sequencer of codes of at least minLen characters
+// of the encoding, shuffled with seed, whose counter starts at zero. It
+// panics if minLen is not between 1 and 10.
+func (e *Encoding) NewSequencer(seed uint64, minLen int) *Sequencer {
+	if minLen < 1 || minLen > maxSequencerLen {
+		panic(fmt.Sprintf("base62: sequencer code length %d out of range [1, %d]", minLen, maxSequencerLen))
+	}
+	s := &Sequencer{enc: e, minLen: minLen}
+	for i := range s.keys {
+		seed = mix64(seed)
+		s.keys[i] = seed
+	}
+	return s
+}
+
+// Next returns the code of the current counter value and increments the
+// counter. It panics once all codes up to 10 characters are used.
+func (s *Sequencer) Next() string {
+	code, err := s.Code(atomic.AddUint64(&s.next, 1) - 1)
+	if err != nil {
+		panic("base62: " + err.Error())
+	}
+	return code
+}
+
+// Code returns the code of counter value n, without changing the counter.
+// An error is returned when n is beyond the codes of 10 characters.
+func (s *Sequencer) Code(n uint64) (string, error) {
+	width, index := s.minLen, n
+	for size := radixPow[width]; index >= size; size = radixPow[width] {
+		index -= size
+		if width++; width > maxSequencerLen {
+			return "", fmt.Errorf("sequencer counter %d exceeds the codes of %d characters", n, maxSequencerLen)
+		}
+	}
+	v := s.permute(index, width)
+	code := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		code[i] = s.enc.alphabet[v%radix]
+		v /= radix
+	}
+	return string(code), nil
+}
+
+// Parse returns the counter value of a code produced by the Sequencer. An
+// error is returned for invalid characters or a length outside the widths
+// of the Sequencer.
+func (s *Sequencer) Parse(code string) (uint64, error) {
+	width := len(code)
+	if width < s.minLen || width > maxSequencerLen {
+		return 0, fmt.Errorf("invalid length %d of a sequencer code %q, want %d to %d", width, code, s.minLen, maxSequencerLen)
+	}
+	var v uint64
+	for i := 0; i < width; i++ {
+		d := s.enc.decodeMap[code[i]]
+		if d == 255 {
+			return 0, fmt.Errorf("invalid character %q in decoding a base62 string %q", code[i], code)
+		}
+		v = v*radix + uint64(d)
+	}
+	n := s.unpermute(v, width)
+	for w := s.minLen; w < width; w++ {
+		n += radixPow[w]
+	}
+	return n, nil
+}
+
+// permute applies the Feistel network to x below 62^width. The value is
+// split into a high part below A = 62^(width/2) and a low part below
+// B = 62^(width-width/2); each round adds a keyed function of the low part
+// to the high part modulo its size and swaps the parts and their sizes.
+func (s *Sequencer) permute(x uint64, width int) uint64 {
+	a, b := radixPow[width/2], radixPow[width-width/2]
+	l, r := x/b, x%b
+	for i := 0; i < feistelRounds; i++ {
+		l = (l + s.round(i, width, r)%a) % a
+		l, r = r, l
+		a, b = b, a
+	}
+	return l*b + r
+}
+
+// unpermute is the inverse of permute.
+func (s *Sequencer) unpermute(x uint64, width int) uint64 {
+	a, b := radixPow[width/2], radixPow[width-width/2]
+	l, r := x/b, x%b
+	for i := feistelRounds - 1; i >= 0; i-- {
+		l, r = r, l
+		a, b = b, a
+		l = (l + a - s.round(i, width, r)%a) % a
+	}
+	return l*b + r
+}
+
+// round is the keyed round function of the Feistel network.
+func (s *Sequencer) round(i, width int, r uint64) uint64 {
+	return mix64(s.keys[i] ^ uint64(width)<<56 ^ r)
+}
+
+// mix64 is the splitmix64 finalizer, a fast bijective scrambling of x.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
@@ -0,0 +1,117 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/schwid/base62"
+)
+
+func TestSequencerUnique(t *testing.T) {
+	const n = 200000
+	s := base62.StdEncoding.NewSequencer(42, 4)
+	seen := make(map[string]bool, n)
+	sequential := 0
+	prev := ""
+	for i := uint64(0); i < n; i++ {
+		code := s.Next()
+		if len(code) != 4 {
+			t.Fatalf("Next() #%d = %q, want 4 characters", i, code)
+		}
+		if seen[code] {
+			t.Fatalf("Next() #%d = %q, already returned", i, code)
+		}
+		seen[code] = true
+		if code > prev {
+			sequential++
+		}
+		prev = code
+		got, err := s.Parse(code)
+		if err != nil || got != i {
+			t.Fatalf("Parse(%q) = %d, %v, want %d", code, got, err, i)
+		}
+	}
+	if sequential > n*3/4 {
+		t.Errorf("%d of %d codes sort after the previous one, want a shuffled order", sequential, n)
+	}
+}
+
+func TestSequencerWidths(t *testing.T) {
+	// the 62 codes of one character, then all 3844 of two, then three
+	s := base62.StdEncoding.NewSequencer(7, 1)
+	seen := make(map[string]bool)
+	for i := uint64(0); i < 62+62*62+10; i++ {
+		code := s.Next()
+		want := 1
+		if i >= 62 {
+			want = 2
+		}
+		if i >= 62+62*62 {
+			want = 3
+		}
+		if len(code) != want || seen[code] {
+			t.Fatalf("Next() #%d = %q, want a new code of %d characters", i, code, want)
+		}
+		seen[code] = true
+		if got, err := s.Parse(code); err != nil || got != i {
+			t.Fatalf("Parse(%q) = %d, %v, want %d", code, got, err, i)
+		}
+	}
+
+	s = base62.StdEncoding.NewSequencer(7, 10)
+	last := uint64(839299365868340223) // 62^10 - 1
+	code, err := s.Code(last)
+	if err != nil || len(code) != 10 {
+		t.Fatalf("Code(%d) = %q, %v, want 10 characters", last, code, err)
+	}
+	if got, err := s.Parse(code); err != nil || got != last {
+		t.Errorf("Parse(%q) = %d, %v, want %d", code, got, err, last)
+	}
+	if code, err := s.Code(last + 1); err == nil {
+		t.Errorf("Code(%d) = %q, want error", last+1, code)
+	}
+}
+
+func TestSequencerSeed(t *testing.T) {
+	a := base62.StdEncoding.NewSequencer(1, 6)
+	b := base62.StdEncoding.NewSequencer(2, 6)
+	same := 0
+	for i := 0; i < 100; i++ {
+		if a.Next() == b.Next() {
+			same++
+		}
+	}
+	if same > 1 {
+		t.Errorf("%d of 100 codes equal for different seeds, want different sequences", same)
+	}
+	c := base62.StdEncoding.NewSequencer(1, 6)
+	a = base62.StdEncoding.NewSequencer(1, 6)
+	for i := 0; i < 100; i++ {
+		if x, y := a.Next(), c.Next(); x != y {
+			t.Fatalf("Next() #%d = %q and %q for the same seed", i, x, y)
+		}
+	}
+}
+
+func TestSequencerParseErrors(t *testing.T) {
+	s := base62.StdEncoding.NewSequencer(0, 4)
+	for _, code := range []string{"", "abc", "ab-d", "abcdefghijk", "abcd" + strings.Repeat("0", 7)} {
+		if n, err := s.Parse(code); err == nil {
+			t.Errorf("Parse(%q) = %d, want error", code, n)
+		}
+	}
+	for _, minLen := range []int{0, 11} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewSequencer(0, %d) did not panic", minLen)
+				}
+			}()
+			base62.StdEncoding.NewSequencer(0, minLen)
+		}()
+	}
+}
@@ -0,0 +1,81 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// EncodeSparse run-length compresses the zero bytes of b before encoding,
+// which makes mostly-zero input such as sparse bitsets much shorter.
+//
+// In the compressed form a zero byte is always followed by a count byte n
+// between 1 and 255 and stands for n zero bytes; runs longer than 255 are
+// split. Every other byte is copied as is.
+func (e *Encoding) EncodeSparse(b []byte) string {
+	var buf []byte
+	for i := 0; i < len(b); {
+		if b[i] != 0 {
+			buf = append(buf, b[i])
+			i++
+			continue
+		}
+		n := 0
+		for i < len(b) && b[i] == 0 && n < 255 {
+			n++
+			i++
+		}
+		buf = append(buf, 0, byte(n))
+	}
+	return e.encode(buf)
+}
+
+// DecodeSparse decodes a string produced by EncodeSparse.
+func (e *Encoding) DecodeSparse(s string) ([]byte, error) {
+	buf, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var out []byte
+	for i := 0; i < len(buf); i++ {
+		if buf[i] != 0 {
+			out = append(out, buf[i])
+			continue
+		}
+		if i+1 == len(buf) || buf[i+1] == 0 {
+			return nil, fmt.Errorf("invalid zero run in decoding a sparse base62 string %q", s)
+		}
+		i++
+		out = append(out, make([]byte, buf[i])...)
+	}
+	return out, nil
+}
+
+// EncodeTrimTrailing encodes b without its trailing zero bytes, for zero
+// padded fixed-size buffers. Being the low-order bytes of the big-endian
+// value, they cannot be cut from the encoding itself, so they are removed
+// from b before encoding and the full length must be passed to
+// DecodeRestoreTrailing. Leading zero bytes are kept.
+func (e *Encoding) EncodeTrimTrailing(b []byte) string {
+	n := len(b)
+	for n > 0 && b[n-1] == 0 {
+		n--
+	}
+	return e.encode(b[:n])
+}
+
+// DecodeRestoreTrailing decodes a string produced by EncodeTrimTrailing and
+// pads the result with zero bytes to totalLen. An error is returned when
+// the decoded bytes are longer than totalLen.
+func (e *Encoding) DecodeRestoreTrailing(s string, totalLen int) ([]byte, error) {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > totalLen {
+		return nil, fmt.Errorf("%d bytes exceed the total length %d in decoding a base62 string %q", len(b), totalLen, s)
+	}
+	out := make([]byte, totalLen)
+	copy(out, b)
+	return out, nil
+}
@@ -0,0 +1,93 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+func TestSparse(t *testing.T) {
+	bitset := make([]byte, 1024)
+	bitset[3] = 0x10
+	bitset[500] = 0x01
+	bitset[1023] = 0x80
+
+	inputs := [][]byte{
+		{},
+		{0},
+		{1, 2, 3},
+		{0, 0, 0, 7},
+		make([]byte, 255),
+		make([]byte, 256),
+		make([]byte, 1000),
+		bitset,
+	}
+	for x, in := range inputs {
+		s := base62.StdEncoding.EncodeSparse(in)
+		got, err := base62.StdEncoding.DecodeSparse(s)
+		if err != nil {
+			t.Errorf("DecodeSparse test #%d failed: %v", x, err)
+			continue
+		}
+		if !bytes.Equal(got, in) {
+			t.Errorf("DecodeSparse test #%d: got %x want %x", x, got, in)
+		}
+	}
+
+	sparse, plain := base62.StdEncoding.EncodeSparse(bitset), base62.StdEncoding.EncodeToString(bitset)
+	if len(sparse) >= len(plain) {
+		t.Errorf("EncodeSparse of a sparse bitset has length %d, want less than %d", len(sparse), len(plain))
+	}
+}
+
+func TestSparseInvalid(t *testing.T) {
+	for _, raw := range [][]byte{{0}, {1, 0}, {0, 0}, {5, 0, 0, 1}} {
+		s := base62.StdEncoding.EncodeToString(raw)
+		if got, err := base62.StdEncoding.DecodeSparse(s); err == nil {
+			t.Errorf("DecodeSparse(%s) = %x, want error", s, got)
+		}
+	}
+}
+
+func TestEncodeTrimTrailing(t *testing.T) {
+	tests := []struct {
+		in  []byte
+		out string
+	}{
+		{nil, ""},
+		{[]byte{0, 0, 0}, ""},
+		{[]byte("abc"), "qMin"},
+		{[]byte("abc\x00\x00\x00\x00\x00"), "qMin"},
+		{[]byte("\x00\x00abc\x00\x00"), "00qMin"},
+		{[]byte("a\x00b\x00"), "qFLI"},
+	}
+	for _, tt := range tests {
+		s := base62.StdEncoding.EncodeTrimTrailing(tt.in)
+		if s != tt.out {
+			t.Errorf("EncodeTrimTrailing(%x) = %q, want %q", tt.in, s, tt.out)
+		}
+		got, err := base62.StdEncoding.DecodeRestoreTrailing(s, len(tt.in))
+		if err != nil || !bytes.Equal(got, tt.in) {
+			t.Errorf("DecodeRestoreTrailing(%q, %d) = %x, %v, want %x", s, len(tt.in), got, err, tt.in)
+		}
+	}
+	buf := make([]byte, 64)
+	copy(buf, "fixed size record")
+	s := base62.StdEncoding.EncodeTrimTrailing(buf)
+	if len(s) >= len(base62.StdEncoding.EncodeToString(buf)) {
+		t.Errorf("EncodeTrimTrailing(%x) = %q, not shorter than EncodeToString", buf, s)
+	}
+	if got, err := base62.StdEncoding.DecodeRestoreTrailing(s, 64); err != nil || !bytes.Equal(got, buf) {
+		t.Errorf("DecodeRestoreTrailing(%q, 64) = %x, %v, want %x", s, got, err, buf)
+	}
+	if got, err := base62.StdEncoding.DecodeRestoreTrailing(s, 16); err == nil {
+		t.Errorf("DecodeRestoreTrailing(%q, 16) = %x, want error", s, got)
+	}
+	if _, err := base62.StdEncoding.DecodeRestoreTrailing("qM?n", 8); err == nil {
+		t.Errorf("DecodeRestoreTrailing(qM?n) expected error")
+	}
+}
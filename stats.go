@@ -0,0 +1,46 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+// DecodeStats reports the work of a decode, see DecodeStringInstrumented.
+// Decoding does not use big.Int: the value is accumulated in 64-bit limbs,
+// folding in groups of up to ten digits, four groups per pass over the
+// limbs, so the cost grows with the number of passes times the number of
+// limbs, quadratically in the input length.
+type DecodeStats struct {
+	// Digits is the number of characters decoded.
+	Digits int
+	// Groups is the number of digit groups folded into the limbs.
+	Groups int
+	// Passes is the number of multiply-add passes over the limbs.
+	Passes int
+	// MulAdds is the number of 64-bit multiply-adds, the unit of cost.
+	MulAdds int
+	// Limbs is the number of 64-bit limbs of the decoded value.
+	Limbs int
+}
+
+// DecodeStringInstrumented is DecodeString returning the work done
+// besides the result, for profiling the cost of decoding for given input
+// sizes. It shares the decoding of DecodeString; only the counting is
+// added. Extended encodings, see Extend, take the arbitrary precision path
+// and only report Digits and Limbs.
+func (e *Encoding) DecodeStringInstrumented(s string) ([]byte, DecodeStats, error) {
+	var stats DecodeStats
+	limbs, err := e.decodeLimbsTo(nil, &stats, s)
+	if err != nil {
+		_, err = e.DecodeString(s)
+		return nil, stats, err
+	}
+	stats.Digits = len(s)
+	stats.Limbs = len(limbs)
+	var numZeros int
+	for numZeros < len(s) && s[numZeros] == e.alphabetIdx0 {
+		numZeros++
+	}
+	val := make([]byte, numZeros+limbsByteLen(limbs))
+	putLimbs(val[numZeros:], limbs)
+	return val, stats, nil
+}
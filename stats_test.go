@@ -0,0 +1,49 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStringInstrumented(t *testing.T) {
+	tests := []struct {
+		in    string
+		stats base62.DecodeStats
+	}{
+		{"", base62.DecodeStats{}},
+		{"z", base62.DecodeStats{Digits: 1, Groups: 1, Passes: 1, MulAdds: 16, Limbs: 1}},
+		{strings.Repeat("z", 10), base62.DecodeStats{Digits: 10, Groups: 1, Passes: 1, MulAdds: 16, Limbs: 1}},
+		{strings.Repeat("z", 40), base62.DecodeStats{Digits: 40, Groups: 4, Passes: 1, MulAdds: 16, Limbs: 4}},
+		{strings.Repeat("z", 41), base62.DecodeStats{Digits: 41, Groups: 5, Passes: 2, MulAdds: 16 + 32, Limbs: 4}},
+		{strings.Repeat("0", 20), base62.DecodeStats{Digits: 20, Groups: 2, Passes: 1, MulAdds: 16, Limbs: 0}},
+	}
+	for _, tt := range tests {
+		got, stats, err := base62.StdEncoding.DecodeStringInstrumented(tt.in)
+		if err != nil {
+			t.Errorf("DecodeStringInstrumented(%s) error = %v", tt.in, err)
+			continue
+		}
+		want, _ := base62.StdEncoding.DecodeString(tt.in)
+		if !bytes.Equal(got, want) {
+			t.Errorf("DecodeStringInstrumented(%s) = %x, want %x", tt.in, got, want)
+		}
+		if stats != tt.stats {
+			t.Errorf("DecodeStringInstrumented(%s) stats = %+v, want %+v", tt.in, stats, tt.stats)
+		}
+	}
+	// the passes grow linearly and the multiply-adds quadratically
+	_, small, _ := base62.StdEncoding.DecodeStringInstrumented(strings.Repeat("z", 400))
+	_, large, _ := base62.StdEncoding.DecodeStringInstrumented(strings.Repeat("z", 800))
+	if large.Passes != 2*small.Passes || large.MulAdds < 3*small.MulAdds {
+		t.Errorf("stats for 400 and 800 digits = %+v and %+v, want linear passes and quadratic multiply-adds", small, large)
+	}
+	if _, _, err := base62.StdEncoding.DecodeStringInstrumented("3mJr?"); err == nil {
+		t.Errorf("DecodeStringInstrumented(3mJr?) expected error")
+	}
+}
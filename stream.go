@@ -0,0 +1,381 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"sync"
+	"time"
+)
+
+// streamBlocks is the number of blocks an encoder buffers before writing.
+const streamBlocks = 512
+
+var errClosed = errors.New("base62: write to closed encoder")
+
+// ErrStreamChecksum is returned by a decoder with VerifyCRC32 when the
+// CRC32 trailer of the stream is missing or does not match the data.
+var ErrStreamChecksum = errors.New("base62: stream checksum mismatch")
+
+type encoder struct {
+	enc    *Encoding
+	w      io.Writer
+	err    error
+	closed bool
+	buf    [streamBlocks * BlockBytes]byte
+	nbuf   int
+	out    [streamBlocks * BlockChars]byte
+
+	// flushBytes and flushDelay are the EncoderOption limits, zero if unset
+	flushBytes int
+	flushDelay time.Duration
+	// mu guards the encoder against the FlushDelay timer
+	mu    sync.Mutex
+	timer *time.Timer
+	// checksum is set by AppendCRC32, crc is the CRC32 of the input so far
+	checksum bool
+	crc      uint32
+}
+
+// An EncoderOption sets when a stream encoder writes its buffered input
+// before the buffer is full. Only whole blocks can be written before
+// Close, so with either option the final partial block, up to 7 bytes,
+// still waits for more input or Close.
+type EncoderOption func(*encoder)
+
+// FlushBytes makes the encoder write its whole buffered blocks as soon as
+// n bytes are buffered. Values of n up to BlockBytes write every complete
+// block right away.
+func FlushBytes(n int) EncoderOption {
+	return func(e *encoder) {
+		e.flushBytes = n
+	}
+}
+
+// FlushDelay makes the encoder write its whole buffered blocks at most d
+// after they were completed, even if no more input arrives. The write
+// happens from a timer goroutine; the encoder is locked meanwhile, so the
+// underlying writer is never used concurrently by the encoder itself.
+func FlushDelay(d time.Duration) EncoderOption {
+	return func(e *encoder) {
+		e.flushDelay = d
+	}
+}
+
+// AppendCRC32 makes the encoder write the IEEE CRC32 of the input at Close,
+// after the final block, as one more 4-byte block of 6 characters. The
+// stream must then be read by a decoder with VerifyCRC32.
+func AppendCRC32() EncoderOption {
+	return func(e *encoder) {
+		e.checksum = true
+	}
+}
+
+// NewEncoder returns a stream encoder that writes the block format (see
+// BlockBytes) of everything written to it to w. Input is buffered and
+// encoded in chunks of whole blocks, so memory use is constant whatever the
+// stream size, and the output is identical to EncodeBlocks of the whole
+// input. The final partial block is only written by Close, which must be
+// called when done. The encoder also implements io.ReaderFrom. The options
+// bound the latency of interactive streams by writing before the buffer is
+// full.
+func NewEncoder(enc *Encoding, w io.Writer, opts ...EncoderOption) io.WriteCloser {
+	e := &encoder{enc: enc, w: w}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (e *encoder) Write(p []byte) (n int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return 0, errClosed
+	}
+	if e.err != nil {
+		return 0, e.err
+	}
+	for len(p) > 0 {
+		k := copy(e.buf[e.nbuf:], p)
+		e.nbuf += k
+		n += k
+		p = p[k:]
+		if e.nbuf == len(e.buf) {
+			if e.err = e.flush(); e.err != nil {
+				return n, e.err
+			}
+		}
+	}
+	if e.flushBytes > 0 && e.nbuf >= e.flushBytes {
+		if e.err = e.flushBlocks(); e.err != nil {
+			return n, e.err
+		}
+	}
+	if e.flushDelay > 0 && e.nbuf >= BlockBytes && e.timer == nil {
+		e.timer = time.AfterFunc(e.flushDelay, e.timedFlush)
+	}
+	return n, nil
+}
+
+// ReadFrom implements io.ReaderFrom, so that io.Copy to the encoder reads
+// r straight into the block buffer, without an intermediate copy. The
+// buffering is that of Write: whole blocks are encoded and written as the
+// buffer fills, or earlier with FlushBytes, and the final partial block is
+// only written by Close. With FlushDelay the input goes through Write, as
+// the timer must be able to flush while r blocks.
+func (e *encoder) ReadFrom(r io.Reader) (n int64, err error) {
+	if e.flushDelay > 0 {
+		// hide ReadFrom from io.Copy
+		return io.Copy(struct{ io.Writer }{e}, r)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return 0, errClosed
+	}
+	if e.err != nil {
+		return 0, e.err
+	}
+	for {
+		k, rerr := r.Read(e.buf[e.nbuf:])
+		e.nbuf += k
+		n += int64(k)
+		if e.nbuf == len(e.buf) {
+			e.err = e.flush()
+		} else if e.flushBytes > 0 && e.nbuf >= e.flushBytes {
+			e.err = e.flushBlocks()
+		}
+		if e.err != nil {
+			return n, e.err
+		}
+		if rerr == io.EOF {
+			return n, nil
+		}
+		if rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
+// timedFlush writes the whole blocks buffered when the FlushDelay timer
+// fires.
+func (e *encoder) timedFlush() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.timer = nil
+	if !e.closed && e.err == nil {
+		e.err = e.flushBlocks()
+	}
+}
+
+// Close flushes any buffered input, including the final partial block.
+// It does not close the underlying writer.
+func (e *encoder) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+	if !e.closed {
+		e.closed = true
+		if e.err == nil {
+			e.err = e.flush()
+		}
+		if e.err == nil && e.checksum {
+			var sum [crc32.Size]byte
+			var out [checksumChars]byte
+			binary.BigEndian.PutUint32(sum[:], e.crc)
+			e.enc.encodeBlock(out[:], sum[:])
+			_, e.err = e.w.Write(out[:])
+		}
+	}
+	return e.err
+}
+
+// flush encodes and writes the buffered input. Only the last flush, from
+// Close, may end with a partial block.
+func (e *encoder) flush() error {
+	return e.flushPrefix(e.nbuf)
+}
+
+// flushBlocks encodes and writes the whole blocks of the buffered input,
+// keeping the partial block buffered.
+func (e *encoder) flushBlocks() error {
+	return e.flushPrefix(e.nbuf / BlockBytes * BlockBytes)
+}
+
+// flushPrefix encodes and writes the first n buffered bytes and moves the
+// rest to the start of the buffer.
+func (e *encoder) flushPrefix(n int) error {
+	src, out := e.buf[:n], 0
+	if e.checksum {
+		e.crc = crc32.Update(e.crc, crc32.IEEETable, src)
+	}
+	for len(src) > 0 {
+		k := len(src)
+		if k > BlockBytes {
+			k = BlockBytes
+		}
+		w := blockWidths[k]
+		e.enc.encodeBlock(e.out[out:out+w], src[:k])
+		src, out = src[k:], out+w
+	}
+	e.nbuf = copy(e.buf[:], e.buf[n:e.nbuf])
+	if out == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.out[:out])
+	return err
+}
+
+type decoder struct {
+	enc    *Encoding
+	r      io.Reader
+	err    error
+	in     [streamBlocks * BlockChars]byte
+	nin    int
+	out    []byte
+	outbuf [streamBlocks * BlockBytes]byte
+	nread  int64
+	// checksum is set by VerifyCRC32, crc is the CRC32 of the output so far
+	checksum bool
+	crc      uint32
+}
+
+// checksumChars is the width of the CRC32 trailer, blockWidths[crc32.Size].
+const checksumChars = 6
+
+// A DecoderOption configures a stream decoder.
+type DecoderOption func(*decoder)
+
+// VerifyCRC32 makes the decoder expect the CRC32 trailer written by an
+// encoder with AppendCRC32, and fail with ErrStreamChecksum at the end of
+// the stream if the trailer is missing or does not match, as happens with a
+// corrupted or truncated stream. The decoded bytes are returned as they
+// arrive, so they are only verified once Read reports io.EOF.
+func VerifyCRC32() DecoderOption {
+	return func(d *decoder) {
+		d.checksum = true
+	}
+}
+
+// NewDecoder returns a stream decoder for the block format read from r.
+// Reads may split blocks anywhere; incomplete blocks are buffered until
+// the rest arrives, and the final partial block is decoded once r reports
+// io.EOF. ASCII spaces, tabs and line breaks are skipped, so wrapped output
+// decodes as is. Any other character outside the alphabet makes Read fail.
+// The decoder also implements io.WriterTo, and has a BytesRead() int64
+// method reporting the number of decoded bytes returned so far.
+//
+// Everything read from r is consumed: the bytes of the blocks before an
+// error are still returned, but the characters of an incomplete block are
+// lost with the decoder. To resume decoding across connections, feed the
+// input to DecodeBlocksPartial, which reports the characters consumed.
+func NewDecoder(enc *Encoding, r io.Reader, opts ...DecoderOption) io.Reader {
+	d := &decoder{enc: enc, r: r}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func (d *decoder) Read(p []byte) (n int, err error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.fill()
+	}
+	n = copy(p, d.out)
+	d.out = d.out[n:]
+	d.nread += int64(n)
+	return n, nil
+}
+
+// BytesRead returns the number of decoded bytes returned by Read and
+// written by WriteTo so far.
+func (d *decoder) BytesRead() int64 {
+	return d.nread
+}
+
+// WriteTo implements io.WriterTo, so that io.Copy from the decoder writes
+// each decoded chunk to w straight from the decoder buffer. It returns nil
+// at the end of the stream and the first read, decode or write error
+// otherwise.
+func (d *decoder) WriteTo(w io.Writer) (n int64, err error) {
+	for {
+		if len(d.out) > 0 {
+			k, werr := w.Write(d.out)
+			n += int64(k)
+			d.nread += int64(k)
+			d.out = d.out[k:]
+			if werr != nil {
+				return n, werr
+			}
+		}
+		if d.err == io.EOF {
+			return n, nil
+		}
+		if d.err != nil {
+			return n, d.err
+		}
+		d.fill()
+	}
+}
+
+// fill reads more input and decodes every complete block, or the final
+// partial block once the input is exhausted.
+func (d *decoder) fill() {
+	n, err := d.r.Read(d.in[d.nin:])
+	for _, c := range d.in[d.nin : d.nin+n] {
+		if !isSpace(c) {
+			d.in[d.nin] = c
+			d.nin++
+		}
+	}
+	atEOF := err == io.EOF
+	data := d.in[:d.nin]
+	if d.checksum {
+		// hold back the characters that may be the trailer
+		if len(data) < checksumChars {
+			if atEOF {
+				d.out, d.err = nil, ErrStreamChecksum
+				return
+			}
+			data = data[:0]
+		} else {
+			data = data[:len(data)-checksumChars]
+		}
+	}
+	out, used, derr := d.enc.DecodeBlocksPartial(d.outbuf[:], data, atEOF)
+	d.out = d.outbuf[:out]
+	if d.checksum {
+		d.crc = crc32.Update(d.crc, crc32.IEEETable, d.out)
+		if derr == nil && atEOF {
+			derr = d.verify(d.in[used:d.nin])
+		}
+	}
+	d.nin = copy(d.in[:], d.in[used:d.nin])
+	if derr != nil {
+		d.err = derr
+		return
+	}
+	d.err = err
+}
+
+// verify checks the CRC32 trailer against the decoded output.
+func (d *decoder) verify(trailer []byte) error {
+	var sum [BlockBytes]byte
+	n, err := d.enc.decodeBlock(sum[:], string(trailer))
+	if err != nil || n != crc32.Size || binary.BigEndian.Uint32(sum[:]) != d.crc {
+		return ErrStreamChecksum
+	}
+	return nil
+}
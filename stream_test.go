@@ -0,0 +1,332 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/schwid/base62"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestEncoder(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 8, 9, 4095, 4096, 4097, 100000} {
+		in := make([]byte, size)
+		rand.Read(in)
+		var out bytes.Buffer
+		w := base62.NewEncoder(base62.StdEncoding, &out)
+		for rest := in; len(rest) > 0; {
+			n := rand.Intn(3000) + 1
+			if n > len(rest) {
+				n = len(rest)
+			}
+			if k, err := w.Write(rest[:n]); err != nil || k != n {
+				t.Fatalf("Write(%d bytes) = %d, %v", n, k, err)
+			}
+			rest = rest[n:]
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close() = %v", err)
+		}
+		if got, want := out.String(), base62.StdEncoding.EncodeBlocks(in); got != want {
+			t.Errorf("NewEncoder output of %d bytes differs from EncodeBlocks", size)
+		}
+	}
+}
+
+func TestEncoderClosed(t *testing.T) {
+	var out bytes.Buffer
+	w := base62.NewEncoder(base62.StdEncoding, &out)
+	w.Write([]byte("abc"))
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close() = %v, want nil", err)
+	}
+	if _, err := w.Write([]byte("d")); err == nil {
+		t.Errorf("Write after Close should fail")
+	}
+	if got, want := out.String(), base62.StdEncoding.EncodeBlocks([]byte("abc")); got != want {
+		t.Errorf("NewEncoder output = %s, want %s", got, want)
+	}
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestEncoderWriteError(t *testing.T) {
+	w := base62.NewEncoder(base62.StdEncoding, failingWriter{})
+	if _, err := w.Write(make([]byte, 10000)); err != errWrite {
+		t.Errorf("Write() = %v, want %v", err, errWrite)
+	}
+	if err := w.Close(); err != errWrite {
+		t.Errorf("Close() = %v, want %v", err, errWrite)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 8, 9, 5631, 5632, 5633, 100000} {
+		in := make([]byte, size)
+		rand.Read(in)
+		encoded := base62.StdEncoding.EncodeBlocks(in)
+		readers := map[string]io.Reader{
+			"plain":    strings.NewReader(encoded),
+			"one byte": iotest.OneByteReader(strings.NewReader(encoded)),
+			"half":     iotest.HalfReader(strings.NewReader(encoded)),
+			"wrapped":  strings.NewReader(wrap(encoded, 76)),
+		}
+		for name, r := range readers {
+			got, err := io.ReadAll(base62.NewDecoder(base62.StdEncoding, r))
+			if err != nil {
+				t.Errorf("NewDecoder(%s) of %d bytes failed: %v", name, size, err)
+				continue
+			}
+			if !bytes.Equal(got, in) {
+				t.Errorf("NewDecoder(%s) of %d bytes returned different data", name, size)
+			}
+		}
+	}
+}
+
+func TestDecoderBytesRead(t *testing.T) {
+	for _, size := range []int{0, 1, 9, 5633, 100000} {
+		in := make([]byte, size)
+		rand.Read(in)
+		r := base62.NewDecoder(base62.StdEncoding, iotest.HalfReader(strings.NewReader(base62.StdEncoding.EncodeBlocks(in))))
+		counter, ok := r.(interface{ BytesRead() int64 })
+		if !ok {
+			t.Fatalf("NewDecoder() has no BytesRead method")
+		}
+		buf := make([]byte, 100)
+		var total int64
+		for {
+			n, err := r.Read(buf)
+			total += int64(n)
+			if got := counter.BytesRead(); got != total {
+				t.Fatalf("BytesRead() after %d bytes of %d = %d", total, size, got)
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Read() of %d bytes failed: %v", size, err)
+			}
+		}
+		if got := counter.BytesRead(); got != int64(size) {
+			t.Errorf("BytesRead() after reading %d bytes = %d", size, got)
+		}
+	}
+}
+
+func TestStreamCRC32(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 8, 9, 5633, 100000} {
+		in := make([]byte, size)
+		rand.Read(in)
+		var buf bytes.Buffer
+		w := base62.NewEncoder(base62.StdEncoding, &buf, base62.AppendCRC32())
+		if _, err := w.Write(in); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if want := len(base62.StdEncoding.EncodeBlocks(in)) + 6; buf.Len() != want {
+			t.Errorf("NewEncoder(AppendCRC32) of %d bytes wrote %d characters, want %d", size, buf.Len(), want)
+		}
+		r := base62.NewDecoder(base62.StdEncoding, iotest.OneByteReader(&buf), base62.VerifyCRC32())
+		got, err := io.ReadAll(r)
+		if err != nil || !bytes.Equal(got, in) {
+			t.Errorf("NewDecoder(VerifyCRC32) of %d bytes = %d bytes, %v", size, len(got), err)
+		}
+	}
+}
+
+func TestStreamCRC32Corrupted(t *testing.T) {
+	in := bytes.Repeat([]byte("checksummed base62 stream "), 500)
+	var buf bytes.Buffer
+	w := base62.NewEncoder(base62.StdEncoding, &buf, base62.AppendCRC32())
+	w.Write(in)
+	w.Close()
+	encoded := buf.String()
+
+	// a digit past the first of its block cannot overflow the block
+	alphabet := base62.StdEncoding.Alphabet()
+	pos := 11*100 + 5
+	c := alphabet[(strings.IndexByte(alphabet, encoded[pos])+1)%len(alphabet)]
+	flipped := encoded[:pos] + string(c) + encoded[pos+1:]
+	for name, s := range map[string]string{"flipped": flipped, "empty": ""} {
+		_, err := io.ReadAll(base62.NewDecoder(base62.StdEncoding, strings.NewReader(s), base62.VerifyCRC32()))
+		if err != base62.ErrStreamChecksum {
+			t.Errorf("NewDecoder(VerifyCRC32) of %s stream = %v, want %v", name, err, base62.ErrStreamChecksum)
+		}
+	}
+	plain := base62.StdEncoding.EncodeBlocks(in)
+	if _, err := io.ReadAll(base62.NewDecoder(base62.StdEncoding, strings.NewReader(plain), base62.VerifyCRC32())); err == nil {
+		t.Errorf("NewDecoder(VerifyCRC32) of a stream without trailer = nil, want error")
+	}
+}
+
+func TestDecoderInvalid(t *testing.T) {
+	valid := base62.StdEncoding.EncodeBlocks([]byte("0123456789abcdef"))
+	for _, s := range []string{
+		valid[:5] + "?" + valid[6:],
+		valid + "0000",
+		valid[:len(valid)-3], // no block is 8 characters long
+	} {
+		got, err := io.ReadAll(base62.NewDecoder(base62.StdEncoding, strings.NewReader(s)))
+		if err == nil {
+			t.Errorf("NewDecoder(%s) = %x, want error", s, got)
+		}
+	}
+}
+
+func wrap(s string, width int) string {
+	var b strings.Builder
+	for len(s) > width {
+		b.WriteString(s[:width])
+		b.WriteString("\r\n")
+		s = s[width:]
+	}
+	b.WriteString(s)
+	b.WriteString("\n")
+	return b.String()
+}
+
+func TestEncoderFlushBytes(t *testing.T) {
+	in := make([]byte, 100)
+	rand.Read(in)
+	var out bytes.Buffer
+	w := base62.NewEncoder(base62.StdEncoding, &out, base62.FlushBytes(20))
+	w.Write(in[:19])
+	if out.Len() != 0 {
+		t.Errorf("output of %d characters below the threshold", out.Len())
+	}
+	w.Write(in[19:21])
+	// 21 bytes buffered, the two whole blocks are written
+	if got, want := out.String(), base62.StdEncoding.EncodeBlocks(in[:16]); got != want {
+		t.Errorf("output after the threshold = %s, want %s", got, want)
+	}
+	w.Write(in[21:])
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if got, want := out.String(), base62.StdEncoding.EncodeBlocks(in); got != want {
+		t.Errorf("NewEncoder output = %s, want %s", got, want)
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe for the FlushDelay timer goroutine.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestEncoderFlushDelayConcurrent(t *testing.T) {
+	in := []byte("hello, world")
+	var out lockedBuffer
+	w := base62.NewEncoder(base62.StdEncoding, &out, base62.FlushDelay(time.Millisecond))
+	w.Write(in)
+	want := base62.StdEncoding.EncodeBlocks(in[:8])
+	for deadline := time.Now().Add(5 * time.Second); out.String() != want; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("output after the delay = %q, want %q", out.String(), want)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if got, want := out.String(), base62.StdEncoding.EncodeBlocks(in); got != want {
+		t.Errorf("NewEncoder output = %s, want %s", got, want)
+	}
+}
+
+func TestEncoderReadFrom(t *testing.T) {
+	for _, opts := range [][]base62.EncoderOption{nil, {base62.FlushBytes(100)}, {base62.FlushDelay(time.Hour)}} {
+		for _, size := range []int{0, 1, 7, 8, 9, 4095, 4096, 4097, 100000} {
+			in := make([]byte, size)
+			rand.Read(in)
+			var out bytes.Buffer
+			w := base62.NewEncoder(base62.StdEncoding, &out, opts...)
+			rf, ok := w.(io.ReaderFrom)
+			if !ok {
+				t.Fatalf("NewEncoder() does not implement io.ReaderFrom")
+			}
+			n, err := rf.ReadFrom(iotest.HalfReader(bytes.NewReader(in)))
+			if err != nil || n != int64(size) {
+				t.Fatalf("ReadFrom(%d bytes) = %d, %v", size, n, err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() = %v", err)
+			}
+			if got, want := out.String(), base62.StdEncoding.EncodeBlocks(in); got != want {
+				t.Errorf("ReadFrom output of %d bytes differs from EncodeBlocks", size)
+			}
+		}
+	}
+
+	w := base62.NewEncoder(base62.StdEncoding, failingWriter{})
+	if _, err := io.Copy(w, bytes.NewReader(make([]byte, 10000))); err != errWrite {
+		t.Errorf("io.Copy() = %v, want %v", err, errWrite)
+	}
+	w = base62.NewEncoder(base62.StdEncoding, io.Discard)
+	if _, err := w.(io.ReaderFrom).ReadFrom(iotest.ErrReader(errWrite)); err != errWrite {
+		t.Errorf("ReadFrom() = %v, want %v", err, errWrite)
+	}
+	w.Close()
+	if _, err := w.(io.ReaderFrom).ReadFrom(strings.NewReader("a")); err == nil {
+		t.Errorf("ReadFrom after Close should fail")
+	}
+}
+
+func TestDecoderWriteTo(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 8, 9, 5631, 5632, 5633, 100000} {
+		in := make([]byte, size)
+		rand.Read(in)
+		enc := base62.StdEncoding.EncodeBlocks(in)
+		r := base62.NewDecoder(base62.StdEncoding, iotest.OneByteReader(strings.NewReader(enc)))
+		wt, ok := r.(io.WriterTo)
+		if !ok {
+			t.Fatalf("NewDecoder() does not implement io.WriterTo")
+		}
+		var out bytes.Buffer
+		n, err := wt.WriteTo(&out)
+		if err != nil || n != int64(size) || !bytes.Equal(out.Bytes(), in) {
+			t.Errorf("WriteTo(%d bytes) = %d, %v", size, n, err)
+		}
+	}
+
+	r := base62.NewDecoder(base62.StdEncoding, strings.NewReader("qMin?"))
+	if _, err := io.Copy(io.Discard, r); err == nil {
+		t.Errorf("io.Copy() of invalid input = nil, want error")
+	}
+	r = base62.NewDecoder(base62.StdEncoding, strings.NewReader(base62.StdEncoding.EncodeBlocks(make([]byte, 100))))
+	if _, err := io.Copy(failingWriter{}, r); err != errWrite {
+		t.Errorf("io.Copy() = %v, want %v", err, errWrite)
+	}
+}
@@ -0,0 +1,67 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// Tag identifies the type of a tagged value.
+type Tag byte
+
+// Tags of the tagged encoding, written as the first character of a record.
+const (
+	TagInt    Tag = 'i'
+	TagBytes  Tag = 'b'
+	TagString Tag = 's'
+)
+
+// TaggedValue is a value of the tagged encoding. Tag selects which of the
+// other fields holds the value.
+type TaggedValue struct {
+	Tag    Tag
+	Int    int64
+	Bytes  []byte
+	String string
+}
+
+// EncodeTagged encodes v as a record of the tagged encoding: the tag
+// character followed by the value, an integer in the zigzag form of
+// EncodeInt64, bytes and strings with their leading zero bytes kept. It
+// panics on an unknown tag.
+func (e *Encoding) EncodeTagged(v TaggedValue) string {
+	switch v.Tag {
+	case TagInt:
+		return string(v.Tag) + e.EncodeInt64(v.Int)
+	case TagBytes:
+		return string(v.Tag) + e.encode(v.Bytes)
+	case TagString:
+		return string(v.Tag) + e.encode([]byte(v.String))
+	}
+	panic(fmt.Sprintf("base62: unknown tag %q", byte(v.Tag)))
+}
+
+// DecodeTagged decodes a record produced by EncodeTagged.
+func (e *Encoding) DecodeTagged(s string) (TaggedValue, error) {
+	if len(s) == 0 {
+		return TaggedValue{}, fmt.Errorf("missing tag in decoding a tagged base62 record")
+	}
+	v := TaggedValue{Tag: Tag(s[0])}
+	var err error
+	switch v.Tag {
+	case TagInt:
+		v.Int, err = e.DecodeToInt64(s[1:])
+	case TagBytes:
+		v.Bytes, err = e.DecodeString(s[1:])
+	case TagString:
+		var b []byte
+		b, err = e.DecodeString(s[1:])
+		v.String = string(b)
+	default:
+		return TaggedValue{}, fmt.Errorf("unknown tag %q in decoding a tagged base62 record %q", s[0], s)
+	}
+	if err != nil {
+		return TaggedValue{}, err
+	}
+	return v, nil
+}
@@ -0,0 +1,47 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var taggedTests = []base62.TaggedValue{
+	{Tag: base62.TagInt, Int: 0},
+	{Tag: base62.TagInt, Int: -42},
+	{Tag: base62.TagInt, Int: math.MinInt64},
+	{Tag: base62.TagBytes, Bytes: []byte{}},
+	{Tag: base62.TagBytes, Bytes: []byte{0, 0, 1, 2}},
+	{Tag: base62.TagString, String: ""},
+	{Tag: base62.TagString, String: "hello, world"},
+}
+
+func TestEncodeTagged(t *testing.T) {
+	for _, v := range taggedTests {
+		s := base62.StdEncoding.EncodeTagged(v)
+		if s[0] != byte(v.Tag) {
+			t.Errorf("EncodeTagged(%+v) = %s, want tag %c first", v, s, v.Tag)
+		}
+		got, err := base62.StdEncoding.DecodeTagged(s)
+		if err != nil || !reflect.DeepEqual(got, v) {
+			t.Errorf("DecodeTagged(%s) = %+v, %v, want %+v", s, got, err, v)
+		}
+	}
+	for _, s := range []string{"", "x12", "i?", "iZZZZZZZZZZZ", "b?"} {
+		if got, err := base62.StdEncoding.DecodeTagged(s); err == nil {
+			t.Errorf("DecodeTagged(%q) = %+v, want error", s, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EncodeTagged with an unknown tag did not panic")
+		}
+	}()
+	base62.StdEncoding.EncodeTagged(base62.TaggedValue{Tag: 'x'})
+}
@@ -0,0 +1,33 @@
+//go:build go1.23
+// +build go1.23
+
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// DecodeTaggedStream returns an iterator over the records read from r,
+// records produced by EncodeTagged and separated by whitespace. A record
+// that does not decode yields its error and iteration goes on with the next
+// one; a read error is yielded last. It needs Go 1.23 for the iter package.
+func (e *Encoding) DecodeTaggedStream(r io.Reader) iter.Seq2[TaggedValue, error] {
+	return func(yield func(TaggedValue, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Split(bufio.ScanWords)
+		for scanner.Scan() {
+			if !yield(e.DecodeTagged(scanner.Text())) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(TaggedValue{}, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+//go:build go1.23
+// +build go1.23
+
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"errors"
+	"github.com/schwid/base62"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTaggedStream(t *testing.T) {
+	var records []string
+	for _, v := range taggedTests {
+		records = append(records, base62.StdEncoding.EncodeTagged(v))
+	}
+	// one record per line, several on the last one, and an unknown tag
+	stream := strings.Join(records[:4], "\n") + "\n" + strings.Join(records[4:], " ") + "\n\tx12\n"
+
+	var got []base62.TaggedValue
+	var errs []error
+	base62.StdEncoding.DecodeTaggedStream(strings.NewReader(stream))(func(v base62.TaggedValue, err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			got = append(got, v)
+		}
+		return true
+	})
+	if !reflect.DeepEqual(got, taggedTests) {
+		t.Errorf("DecodeTaggedStream = %+v, want %+v", got, taggedTests)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "unknown tag") {
+		t.Errorf("DecodeTaggedStream errors = %v, want one unknown tag error", errs)
+	}
+
+	// stopping early
+	n := 0
+	base62.StdEncoding.DecodeTaggedStream(strings.NewReader(stream))(func(base62.TaggedValue, error) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("DecodeTaggedStream yielded %d values after stopping at 2", n)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
+
+var errRead = errors.New("read failed")
+
+func TestDecodeTaggedStreamReadError(t *testing.T) {
+	var errs []error
+	base62.StdEncoding.DecodeTaggedStream(errReader{})(func(v base62.TaggedValue, err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+	if len(errs) != 1 || errs[0] != errRead {
+		t.Errorf("DecodeTaggedStream errors = %v, want %v", errs, errRead)
+	}
+}
@@ -0,0 +1,25 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+// Bytes is a byte slice that marshals to text, and so to JSON, XML and
+// similar formats, as its StdEncoding base62 string.
+type Bytes []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bytes) MarshalText() ([]byte, error) {
+	return []byte(StdEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. On a decode error the
+// error is returned and b is left unchanged.
+func (b *Bytes) UnmarshalText(text []byte) error {
+	v, err := StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
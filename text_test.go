@@ -0,0 +1,51 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"github.com/schwid/base62"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = base62.Bytes(nil)
+	_ encoding.TextUnmarshaler = (*base62.Bytes)(nil)
+)
+
+type textRecord struct {
+	ID   base62.Bytes `json:"id"`
+	Name string       `json:"name"`
+}
+
+func TestBytesJSON(t *testing.T) {
+	for _, id := range [][]byte{{}, {0}, {0, 0, 1}, []byte("Hello, World!")} {
+		in := textRecord{ID: id, Name: "x"}
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("json.Marshal(%x) error = %v", id, err)
+		}
+		want := `{"id":"` + base62.StdEncoding.EncodeToString(id) + `","name":"x"}`
+		if string(data) != want {
+			t.Errorf("json.Marshal(%x) = %s, want %s", id, data, want)
+		}
+		var out textRecord
+		if err := json.Unmarshal(data, &out); err != nil || !bytes.Equal(out.ID, id) {
+			t.Errorf("json.Unmarshal(%s) = %x, %v, want %x", data, out.ID, err, id)
+		}
+	}
+}
+
+func TestBytesUnmarshalError(t *testing.T) {
+	out := textRecord{ID: base62.Bytes("keep")}
+	if err := json.Unmarshal([]byte(`{"id":"a?b"}`), &out); err == nil {
+		t.Errorf("json.Unmarshal of an invalid id = nil, want error")
+	}
+	if string(out.ID) != "keep" {
+		t.Errorf("json.Unmarshal of an invalid id changed the value to %q", out.ID)
+	}
+}
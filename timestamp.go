@@ -0,0 +1,62 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// WithClock returns a copy of the encoding that reads the current time
+// from now instead of time.Now, which makes expiring tokens testable.
+func (e *Encoding) WithClock(now func() time.Time) *Encoding {
+	c := *e
+	c.now = now
+	return &c
+}
+
+func (e *Encoding) clock() time.Time {
+	if e.now != nil {
+		return e.now()
+	}
+	return time.Now()
+}
+
+// EncodeWithTimestamp prepends the current Unix time in seconds, as 8
+// big-endian bytes, to payload and encodes the result.
+func (e *Encoding) EncodeWithTimestamp(payload []byte) string {
+	buf := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint64(buf, uint64(e.clock().Unix()))
+	copy(buf[8:], payload)
+	return e.encode(buf)
+}
+
+// MaxClockSkew is how far in the future the timestamp of a token may be,
+// to allow for clocks of different machines not being quite in sync.
+const MaxClockSkew = time.Minute
+
+// DecodeWithTimestamp decodes a string produced by EncodeWithTimestamp and
+// returns the payload, or an error if the token is older than maxAge or
+// created more than MaxClockSkew in the future, which a forged token that
+// never expires would be.
+func (e *Encoding) DecodeWithTimestamp(s string, maxAge time.Duration) ([]byte, error) {
+	buf, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < 8 {
+		return nil, fmt.Errorf("missing timestamp in decoding a base62 string %q", s)
+	}
+	created := time.Unix(int64(binary.BigEndian.Uint64(buf)), 0)
+	age := e.clock().Sub(created)
+	if age < -MaxClockSkew {
+		return nil, fmt.Errorf("base62 token %q created %v in the future", s, -age)
+	}
+	if age > maxAge {
+		return nil, fmt.Errorf("expired base62 token %q created %v ago, max age %v", s, age, maxAge)
+	}
+	return buf[8:], nil
+}
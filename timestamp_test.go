@@ -0,0 +1,53 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	enc := base62.StdEncoding.WithClock(func() time.Time { return now })
+	payload := []byte{0, 1, 2, 3}
+	token := enc.EncodeWithTimestamp(payload)
+
+	now = now.Add(30 * time.Minute)
+	got, err := enc.DecodeWithTimestamp(token, time.Hour)
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("DecodeWithTimestamp of a fresh token = %x, %v, want %x", got, err, payload)
+	}
+
+	now = now.Add(time.Hour)
+	if got, err := enc.DecodeWithTimestamp(token, time.Hour); err == nil {
+		t.Errorf("DecodeWithTimestamp of an expired token = %x, want error", got)
+	}
+
+	// a little skew is allowed, a forged far-future token is not
+	future := enc.WithClock(func() time.Time { return now.Add(30 * time.Second) }).EncodeWithTimestamp(payload)
+	if got, err := enc.DecodeWithTimestamp(future, time.Hour); err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("DecodeWithTimestamp of a token 30s ahead = %x, %v, want %x", got, err, payload)
+	}
+	for _, ahead := range []time.Duration{2 * time.Minute, 100 * 365 * 24 * time.Hour} {
+		forged := enc.WithClock(func() time.Time { return now.Add(ahead) }).EncodeWithTimestamp(payload)
+		if got, err := enc.DecodeWithTimestamp(forged, time.Hour); err == nil {
+			t.Errorf("DecodeWithTimestamp of a token %v ahead = %x, want error", ahead, got)
+		}
+	}
+
+	if got, err := enc.DecodeWithTimestamp("qMin", time.Hour); err == nil {
+		t.Errorf("DecodeWithTimestamp of a token without timestamp = %x, want error", got)
+	}
+}
+
+func TestTimestampSystemClock(t *testing.T) {
+	token := base62.StdEncoding.EncodeWithTimestamp([]byte("abc"))
+	if got, err := base62.StdEncoding.DecodeWithTimestamp(token, time.Minute); err != nil || string(got) != "abc" {
+		t.Errorf("DecodeWithTimestamp(%s) = %q, %v, want abc", token, got, err)
+	}
+}
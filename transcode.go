@@ -0,0 +1,53 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"fmt"
+	"io"
+)
+
+// Transcode copies base62 text from r to w, rewriting every character from
+// the alphabet of from into the alphabet of to. All formats of this package
+// are positional, so the digits can be translated one by one without
+// decoding: the input is streamed in chunks and may be of any size, which
+// makes Transcode suited to migrating large block-encoded files. Spaces,
+// tabs and line breaks that are not part of the alphabet are copied as is.
+//
+// Both encodings must have the same radix, otherwise a *RadixError is
+// returned before anything is read.
+func Transcode(from, to *Encoding, r io.Reader, w io.Writer) error {
+	if err := from.Compatible(to); err != nil {
+		return err
+	}
+	buf := make([]byte, 32*1024)
+	var offset int64
+	for {
+		n, err := r.Read(buf)
+		for i, c := range buf[:n] {
+			d := from.digitMap()[c]
+			if d != 255 {
+				buf[i] = to.digitChar(d)
+			} else if !isSpace(c) {
+				return fmt.Errorf("invalid character %q at offset %d in transcoding a base62 stream", c, offset+int64(i))
+			}
+		}
+		offset += int64(n)
+		if _, werr := w.Write(buf[:n]); werr != nil {
+			return werr
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// isSpace reports whether c is an ASCII space, tab or line break.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
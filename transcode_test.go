@@ -0,0 +1,48 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"bytes"
+	"github.com/schwid/base62"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestTranscode(t *testing.T) {
+	in := make([]byte, 1<<20)
+	rand.Read(in)
+	file := base62.StdEncoding.EncodeBlocks(in)
+
+	var gmp bytes.Buffer
+	if err := base62.Transcode(base62.StdEncoding, base62.GMPEncoding, strings.NewReader(file), &gmp); err != nil {
+		t.Fatalf("Transcode to the sorted alphabet failed: %v", err)
+	}
+	if got, err := base62.GMPEncoding.DecodeBlocks(gmp.String()); err != nil || !bytes.Equal(got, in) {
+		t.Fatalf("DecodeBlocks of the transcoded file failed: %v", err)
+	}
+
+	var back bytes.Buffer
+	if err := base62.Transcode(base62.GMPEncoding, base62.StdEncoding, &gmp, &back); err != nil {
+		t.Fatalf("Transcode back to the standard alphabet failed: %v", err)
+	}
+	if back.String() != file {
+		t.Errorf("Transcode round trip does not reproduce the original file")
+	}
+}
+
+func TestTranscodeLines(t *testing.T) {
+	var out bytes.Buffer
+	if err := base62.Transcode(base62.StdEncoding, base62.GMPEncoding, strings.NewReader("qMin\n3h7\r\n"), &out); err != nil {
+		t.Fatalf("Transcode failed: %v", err)
+	}
+	if got, want := out.String(), "QmIN\n3H7\r\n"; got != want {
+		t.Errorf("Transcode = %q, want %q", got, want)
+	}
+	if err := base62.Transcode(base62.StdEncoding, base62.GMPEncoding, strings.NewReader("qM?n"), &out); err == nil {
+		t.Errorf("Transcode of an invalid character should fail")
+	}
+}
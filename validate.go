@@ -0,0 +1,56 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import "fmt"
+
+// MatchesCharset reports whether every byte of s is in the alphabet, the
+// cheap gate before a decode. It stops at the first invalid byte and does
+// no arithmetic, so it runs in a fraction of the time of a decode. The
+// empty string matches.
+func (e *Encoding) MatchesCharset(s string) bool {
+	m := e.digitMap()
+	for i := 0; i < len(s); i++ {
+		if m[s[i]] == 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidString reports whether s consists of alphabet characters only, so
+// that DecodeString succeeds on it. It is MatchesCharset under the name of
+// the other validity checks.
+func (e *Encoding) IsValidString(s string) bool {
+	return e.MatchesCharset(s)
+}
+
+// Valid is IsValidString for a byte slice, without converting it.
+func (e *Encoding) Valid(b []byte) bool {
+	m := e.digitMap()
+	for _, c := range b {
+		if m[c] == 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeStrict decodes s like DecodeString and returns an error unless s is
+// exactly EncodeToString of the result, the canonical form of the value, so
+// that no two accepted strings alias the same key. Without the canonical
+// option every valid string is already in that form, leading zero digits
+// standing for leading zero bytes; with WithCanonicalEncode, leading zero
+// digits are rejected.
+func (e *Encoding) DecodeStrict(s string) ([]byte, error) {
+	b, err := e.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if e.EncodeToString(b) != s {
+		return nil, fmt.Errorf("non-canonical encoding in decoding a base62 string %q", s)
+	}
+	return b, nil
+}
@@ -0,0 +1,79 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"testing"
+)
+
+var charsetTests = []struct {
+	in   string
+	want bool
+}{
+	{"", true},
+	{"0", true},
+	{"0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ", true},
+	{"abc-def", false},
+	{"abc def", false},
+	{"abc\x00", false},
+	{"\xffabc", false},
+	{"日本", false},
+}
+
+func TestMatchesCharset(t *testing.T) {
+	for _, tt := range charsetTests {
+		if got := base62.StdEncoding.MatchesCharset(tt.in); got != tt.want {
+			t.Errorf("MatchesCharset(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidString(t *testing.T) {
+	for _, test := range invalidStringTests {
+		if base62.StdEncoding.IsValidString(test.in) || base62.StdEncoding.Valid([]byte(test.in)) {
+			t.Errorf("IsValidString(%q) = true, want false", test.in)
+		}
+	}
+	for _, test := range stringTests {
+		if !base62.StdEncoding.IsValidString(test.out) || !base62.StdEncoding.Valid([]byte(test.out)) {
+			t.Errorf("IsValidString(%q) = false, want true", test.out)
+		}
+	}
+	for _, tt := range charsetTests {
+		if got := base62.StdEncoding.Valid([]byte(tt.in)); got != tt.want {
+			t.Errorf("Valid(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { base62.StdEncoding.IsValidString("3mJr0") }); n != 0 {
+		t.Errorf("IsValidString allocates %v times, want 0", n)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	canonical := base62.StdEncoding.WithCanonicalEncode()
+	for _, test := range stringTests {
+		for _, enc := range []*base62.Encoding{base62.StdEncoding, canonical} {
+			s := enc.EncodeToString([]byte(test.in))
+			got, err := enc.DecodeStrict(s)
+			if err != nil || enc.EncodeToString(got) != s {
+				t.Errorf("DecodeStrict(%q) = %x, %v", s, got, err)
+			}
+		}
+	}
+	for _, s := range []string{"0", "01", "00qMin"} {
+		if got, err := base62.StdEncoding.DecodeStrict(s); err != nil || len(got) < 1 || got[0] != 0 {
+			t.Errorf("DecodeStrict(%q) = %x, %v, want leading zero bytes", s, got, err)
+		}
+		if got, err := canonical.DecodeStrict(s); err == nil {
+			t.Errorf("canonical DecodeStrict(%q) = %x, want non-canonical error", s, got)
+		}
+	}
+	for _, test := range invalidStringTests {
+		if _, err := base62.StdEncoding.DecodeStrict(test.in); err == nil {
+			t.Errorf("DecodeStrict(%q) expected error", test.in)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// SelfTest round-trips a set of representative inputs through the encoding
+// and returns an error describing the first one that does not survive.
+// It is meant as a quick sanity check for custom alphabets.
+func (e *Encoding) SelfTest() error {
+	inputs := [][]byte{{}}
+	for i := 0; i < 256; i++ {
+		inputs = append(inputs, []byte{byte(i)})
+	}
+	for _, n := range []int{1, 2, 10, 33} {
+		inputs = append(inputs, make([]byte, n))
+	}
+	rnd := rand.New(rand.NewSource(62))
+	for n := 1; n <= 64; n++ {
+		b := make([]byte, n)
+		rnd.Read(b)
+		inputs = append(inputs, b)
+	}
+	for _, in := range inputs {
+		s := e.EncodeToString(in)
+		out, err := e.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("self test failed for input %x: %v", in, err)
+		}
+		want := in
+		if e.canonical {
+			want = trimLeadingZeros(in)
+		}
+		if !bytes.Equal(want, out) {
+			return fmt.Errorf("self test failed for input %x: decoded %q as %x", in, s, out)
+		}
+	}
+	for _, n := range []uint64{0, 1, radix - 1, radix, 1<<64 - 1, rnd.Uint64()} {
+		s := e.EncodeUint64(n)
+		m, err := e.DecodeToUint64(s)
+		if err != nil {
+			return fmt.Errorf("self test failed for integer %d: %v", n, err)
+		}
+		if m != n {
+			return fmt.Errorf("self test failed for integer %d: decoded %q as %d", n, s, m)
+		}
+	}
+	return nil
+}
+
+// maxInjectiveLen bounds CheckInjective, which enumerates radix^maxLen
+// strings.
+const maxInjectiveLen = 3
+
+// CheckInjective decodes every digit sequence of 1 to maxLen digits,
+// rendered through the alphabet, and returns an error if two distinct
+// sequences decode to the same bytes. Leading zero digits are kept as zero
+// bytes by DecodeString, so sequences differing only there never collide;
+// any collision points to an alphabet bug such as a duplicated character.
+// The check is exponential in maxLen, which may be at most 3.
+func (e *Encoding) CheckInjective(maxLen int) error {
+	if maxLen < 1 || maxLen > maxInjectiveLen {
+		return fmt.Errorf("injectivity check length %d out of range [1, %d]", maxLen, maxInjectiveLen)
+	}
+	seen := make(map[string][]byte)
+	digits := make([]byte, 0, maxLen)
+	var walk func() error
+	walk = func() error {
+		if len(digits) > 0 {
+			s := make([]byte, len(digits))
+			for i, d := range digits {
+				s[i] = e.alphabet[d]
+			}
+			out, err := e.DecodeString(string(s))
+			if err != nil {
+				return fmt.Errorf("injectivity check failed for digits %v: %v", digits, err)
+			}
+			if prev, ok := seen[string(out)]; ok {
+				return fmt.Errorf("injectivity check failed: digits %v and %v both decode to %x", prev, digits, out)
+			}
+			seen[string(out)] = append([]byte(nil), digits...)
+		}
+		if len(digits) == maxLen {
+			return nil
+		}
+		for d := byte(0); d < byte(radix); d++ {
+			digits = append(digits, d)
+			if err := walk(); err != nil {
+				return err
+			}
+			digits = digits[:len(digits)-1]
+		}
+		return nil
+	}
+	return walk()
+}
@@ -0,0 +1,36 @@
+/**
+  Copyright (c) 2022 Zander Schwid & Co. LLC. All rights reserved.
+*/
+
+package base62_test
+
+import (
+	"github.com/schwid/base62"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := base62.StdEncoding.SelfTest(); err != nil {
+		t.Errorf("StdEncoding.SelfTest() = %v, want nil", err)
+	}
+	// 'a' appears twice, so one of the digits can never be decoded
+	broken := base62.NewUnchecked([]byte("0123456789aacdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"))
+	if err := broken.SelfTest(); err == nil {
+		t.Errorf("SelfTest() of a duplicated alphabet = nil, want error")
+	}
+}
+
+func TestCheckInjective(t *testing.T) {
+	if err := base62.StdEncoding.CheckInjective(2); err != nil {
+		t.Errorf("StdEncoding.CheckInjective(2) = %v, want nil", err)
+	}
+	broken := base62.NewUnchecked([]byte("0123456789aacdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"))
+	if err := broken.CheckInjective(1); err == nil {
+		t.Errorf("CheckInjective(1) of a duplicated alphabet = nil, want error")
+	}
+	for _, n := range []int{0, 4} {
+		if err := base62.StdEncoding.CheckInjective(n); err == nil {
+			t.Errorf("CheckInjective(%d) = nil, want range error", n)
+		}
+	}
+}